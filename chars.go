@@ -0,0 +1,104 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"context"
+	"unicode/utf8"
+)
+
+// CharDiff represents the diff result for a single character
+// (rune or grapheme cluster).
+type CharDiff struct {
+	// Op indicates the diff operation associated with this character.
+	Op Op
+	// Text contains the actual character (the left one in case of EqlOp).
+	Text string
+	// LeftByteOffset contains the byte offset of the character within the
+	// left string. In case of AddOp, it contains the offset at which the
+	// character is inserted.
+	LeftByteOffset int
+	// LeftRuneOffset contains the rune offset of the character within the
+	// left string (see LeftByteOffset).
+	LeftRuneOffset int
+	// RightByteOffset contains the byte offset of the character within the
+	// right string. In case of DelOp, it contains the offset at which the
+	// character has been deleted.
+	RightByteOffset int
+	// RightRuneOffset contains the rune offset of the character within the
+	// right string (see RightByteOffset).
+	RightRuneOffset int
+}
+
+// DiffStrings runs a diff operation on the characters of the two given strings
+// using the given diff options.
+//
+// Per default the strings are compared rune by rune. Use [WithGraphemeClusters]
+// to compare user-perceived characters instead. Beside this, only the options
+// controlling the diff algorithm are considered (e.g. [WithAlgorithm]).
+func DiffStrings(left string, right string, opts ...DiffOption) []CharDiff {
+	// a background context is never canceled and hence there is no error
+	diffs, _ := DiffStringsContext(context.Background(), left, right, opts...)
+	return diffs
+}
+
+// DiffStringsContext runs a diff operation on the characters of the two given
+// strings using the given diff options and context.
+//
+// If the context is canceled while the diff operation is running, the
+// context's error is returned.
+func DiffStringsContext(ctx context.Context, left string, right string, opts ...DiffOption) ([]CharDiff, error) {
+	differ := NewDiffer(opts...)
+	split := splitRunes
+	if differ.graphemes {
+		split = splitGraphemes
+	}
+	leftChars := split(left)
+	rightChars := split(right)
+	script, err := runEditScript(ctx, differ, leftChars, rightChars, equalComparable, internComparable)
+	if err != nil {
+		return nil, err
+	}
+	edits := cleanupEdits(differ, script.Edits, leftChars, rightChars, equalComparable, nil, nil)
+	diffs := make([]CharDiff, 0, len(edits))
+	leftByte, leftRune, rightByte, rightRune := 0, 0, 0, 0
+	for _, edit := range edits {
+		diff := CharDiff{
+			Op:              edit.Op,
+			LeftByteOffset:  leftByte,
+			LeftRuneOffset:  leftRune,
+			RightByteOffset: rightByte,
+			RightRuneOffset: rightRune,
+		}
+		if edit.Op != AddOp {
+			diff.Text = edit.Left
+			leftByte += len(edit.Left)
+			leftRune += utf8.RuneCountInString(edit.Left)
+		}
+		if edit.Op != DelOp {
+			if edit.Op == AddOp {
+				diff.Text = edit.Right
+			}
+			rightByte += len(edit.Right)
+			rightRune += utf8.RuneCountInString(edit.Right)
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// WithGraphemeClusters enables the comparison of grapheme clusters
+// (user-perceived characters) instead of runes for [DiffStrings].
+//
+// The grapheme clusters are determined according to a simplified variant
+// of the Unicode text segmentation rules. E.g. a letter followed by
+// combining marks or an emoji sequence are compared as a single character.
+func WithGraphemeClusters() DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.graphemes = true
+	})
+}
@@ -0,0 +1,94 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestDiffStrings(t *testing.T) {
+	require.Equal(t, []diff.CharDiff{
+		{Op: diff.EqlOp, Text: "c", LeftByteOffset: 0, LeftRuneOffset: 0, RightByteOffset: 0, RightRuneOffset: 0},
+		{Op: diff.EqlOp, Text: "o", LeftByteOffset: 1, LeftRuneOffset: 1, RightByteOffset: 1, RightRuneOffset: 1},
+		{Op: diff.EqlOp, Text: "n", LeftByteOffset: 2, LeftRuneOffset: 2, RightByteOffset: 2, RightRuneOffset: 2},
+		{Op: diff.AddOp, Text: "f", LeftByteOffset: 3, LeftRuneOffset: 3, RightByteOffset: 3, RightRuneOffset: 3},
+		{Op: diff.EqlOp, Text: "i", LeftByteOffset: 3, LeftRuneOffset: 3, RightByteOffset: 4, RightRuneOffset: 4},
+		{Op: diff.DelOp, Text: "g", LeftByteOffset: 4, LeftRuneOffset: 4, RightByteOffset: 5, RightRuneOffset: 5},
+		{Op: diff.EqlOp, Text: "g", LeftByteOffset: 5, LeftRuneOffset: 5, RightByteOffset: 5, RightRuneOffset: 5},
+	}, diff.DiffStrings("conigg", "config"))
+	require.Equal(t, []diff.CharDiff{
+		{Op: diff.EqlOp, Text: "ä", LeftByteOffset: 0, LeftRuneOffset: 0, RightByteOffset: 0, RightRuneOffset: 0},
+		{Op: diff.DelOp, Text: "ö", LeftByteOffset: 2, LeftRuneOffset: 1, RightByteOffset: 2, RightRuneOffset: 1},
+		{Op: diff.AddOp, Text: "o", LeftByteOffset: 4, LeftRuneOffset: 2, RightByteOffset: 2, RightRuneOffset: 1},
+		{Op: diff.EqlOp, Text: "ü", LeftByteOffset: 4, LeftRuneOffset: 2, RightByteOffset: 3, RightRuneOffset: 2},
+	}, diff.DiffStrings("äöü", "äoü"))
+	require.Empty(t, diff.DiffStrings("", ""))
+}
+
+func TestDiffStringsInvalidUTF8(t *testing.T) {
+	require.Equal(t, []diff.CharDiff{
+		{Op: diff.EqlOp, Text: "a", LeftByteOffset: 0, LeftRuneOffset: 0, RightByteOffset: 0, RightRuneOffset: 0},
+		{Op: diff.DelOp, Text: "\xff", LeftByteOffset: 1, LeftRuneOffset: 1, RightByteOffset: 1, RightRuneOffset: 1},
+		{Op: diff.EqlOp, Text: "b", LeftByteOffset: 2, LeftRuneOffset: 2, RightByteOffset: 1, RightRuneOffset: 1},
+	}, diff.DiffStrings("a\xffb", "ab"))
+}
+
+func TestDiffStringsGraphemeClusters(t *testing.T) {
+	// combining acute accent
+	require.Equal(t, []diff.CharDiff{
+		{Op: diff.EqlOp, Text: "caf", LeftByteOffset: 0, LeftRuneOffset: 0, RightByteOffset: 0, RightRuneOffset: 0},
+		{Op: diff.DelOp, Text: "e\u0301", LeftByteOffset: 3, LeftRuneOffset: 3, RightByteOffset: 3, RightRuneOffset: 3},
+		{Op: diff.AddOp, Text: "e", LeftByteOffset: 6, LeftRuneOffset: 5, RightByteOffset: 3, RightRuneOffset: 3},
+	}, testJoinEqual(diff.DiffStrings("cafe\u0301", "cafe", diff.WithGraphemeClusters())))
+	require.Equal(t, []diff.CharDiff{
+		{Op: diff.EqlOp, Text: "cafe", LeftByteOffset: 0, LeftRuneOffset: 0, RightByteOffset: 0, RightRuneOffset: 0},
+		{Op: diff.DelOp, Text: "\u0301", LeftByteOffset: 4, LeftRuneOffset: 4, RightByteOffset: 4, RightRuneOffset: 4},
+	}, testJoinEqual(diff.DiffStrings("cafe\u0301", "cafe")))
+	// regional indicator pairs (flags)
+	require.Equal(t, []string{"🇩🇪", "🇫🇷", "🇮🇹"}, testGraphemes("🇩🇪🇫🇷🇮🇹"))
+	// emoji ZWJ sequence and skin tone modifier
+	require.Equal(t, []string{"👨‍👩‍👧", "👍🏽", "x"}, testGraphemes("👨‍👩‍👧👍🏽x"))
+	// Hangul jamo and syllables
+	require.Equal(t, []string{"각", "한", "글"}, testGraphemes("각한글"))
+	// CR LF and controls
+	require.Equal(t, []string{"a", "\r\n", "\n", "\t", "b"}, testGraphemes("a\r\n\n\tb"))
+	// spacing mark
+	require.Equal(t, []string{"न", "म", "स्", "ते"}, testGraphemes("नमस्ते"))
+}
+
+func TestDiffStringsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := diff.DiffStringsContext(ctx, strings.Repeat("ab", 1000), strings.Repeat("ba", 1000))
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func testGraphemes(text string) []string {
+	graphemes := make([]string, 0)
+	for _, diff := range diff.DiffStrings(text, "", diff.WithGraphemeClusters()) {
+		graphemes = append(graphemes, diff.Text)
+	}
+	return graphemes
+}
+
+func testJoinEqual(diffs []diff.CharDiff) []diff.CharDiff {
+	joined := make([]diff.CharDiff, 0, len(diffs))
+	for _, d := range diffs {
+		last := len(joined) - 1
+		if last >= 0 && d.Op == diff.EqlOp && joined[last].Op == diff.EqlOp {
+			joined[last].Text += d.Text
+			continue
+		}
+		joined = append(joined, d)
+	}
+	return joined
+}
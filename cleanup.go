@@ -0,0 +1,329 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+// CleanupFlag defines the post-processing passes applied to a diff result
+// to make it more readable (see [WithCleanup]).
+type CleanupFlag int
+
+const (
+	// CompactCleanup slides ambiguous blocks of changes as far down as
+	// possible and aligns them with the changes of the other side (like
+	// git diff --no-indent-heuristic).
+	CompactCleanup CleanupFlag = 1 << iota
+	// IndentCleanup slides ambiguous blocks of changes like CompactCleanup, but
+	// places them at blank line and indentation boundaries (like git's indent
+	// heuristic). For elements other than lines it behaves like CompactCleanup.
+	IndentCleanup
+	// SemanticCleanup merges equalities, which are smaller than the changes on
+	// both sides, into the surrounding changes (like diff-match-patch's semantic
+	// cleanup). This increases the number of edits in favour of fewer and
+	// larger blocks of changes.
+	SemanticCleanup
+)
+
+// WithCleanup enables the given post-processing passes.
+//
+// The diff algorithms produce minimal edit scripts, which are not necessarily
+// the most readable ones. E.g. an added function may start with the closing
+// brace of the previous function. The cleanup passes shift and merge the
+// changes to get closer to what a human would consider the actual change.
+// Per default no cleanup is applied.
+func WithCleanup(flags ...CleanupFlag) DiffOption {
+	var checkedFlags CleanupFlag
+	for _, flag := range flags {
+		checkedFlags |= flag
+	}
+	return DiffOptionFunc(func(d *Differ) {
+		d.cleanup = checkedFlags
+	})
+}
+
+// cleanupEdits applies the cleanup passes selected for the given Differ
+// instance. The lines are used by the indent heuristic only and may be nil.
+func cleanupEdits[T any](d *Differ, edits []Edit[T], left []T, right []T, equal func(T, T) bool, leftLines []string, rightLines []string) []Edit[T] {
+	if d.cleanup == 0 {
+		return edits
+	}
+	if d.cleanup&SemanticCleanup != 0 {
+		edits = cleanupSemantic(edits, left, right)
+	}
+	if d.cleanup&(CompactCleanup|IndentCleanup) != 0 {
+		leftChanged := make([]bool, len(left))
+		rightChanged := make([]bool, len(right))
+		for _, edit := range edits {
+			switch edit.Op {
+			case DelOp:
+				leftChanged[edit.LeftIndex] = true
+			case AddOp:
+				rightChanged[edit.RightIndex] = true
+			}
+		}
+		leftSide := &compactSide[T]{elements: left, equal: equal, lines: leftLines, changed: leftChanged}
+		rightSide := &compactSide[T]{elements: right, equal: equal, lines: rightLines, changed: rightChanged}
+		var shift func(*compactSide[T], changeGroup, int) int
+		if d.cleanup&IndentCleanup != 0 {
+			shift = (*compactSide[T]).bestShift
+		}
+		compactChanges(leftSide, rightSide, shift)
+		compactChanges(rightSide, leftSide, shift)
+		edits = changedEdits(left, right, leftChanged, rightChanged)
+	}
+	return edits
+}
+
+// changeRun represents either a run of equal elements (dels and adds are 0)
+// or a block of changes.
+type changeRun struct {
+	eqls int
+	dels int
+	adds int
+}
+
+// cleanupSemantic eliminates equalities not longer than the changes before
+// and after them.
+func cleanupSemantic[T any](edits []Edit[T], left []T, right []T) []Edit[T] {
+	runs := make([]changeRun, 0)
+	for _, edit := range edits {
+		last := len(runs) - 1
+		if edit.Op == EqlOp {
+			if last >= 0 && runs[last].eqls > 0 {
+				runs[last].eqls++
+			} else {
+				runs = append(runs, changeRun{eqls: 1})
+			}
+			continue
+		}
+		if last < 0 || runs[last].eqls > 0 {
+			runs = append(runs, changeRun{})
+			last++
+		}
+		if edit.Op == DelOp {
+			runs[last].dels++
+		} else {
+			runs[last].adds++
+		}
+	}
+	// runs alternate between equalities and changes
+	for i := 1; i < len(runs)-1; {
+		if runs[i].eqls == 0 {
+			i++
+			continue
+		}
+		before := runs[i-1]
+		after := runs[i+1]
+		if runs[i].eqls > max(before.dels, before.adds) || runs[i].eqls > max(after.dels, after.adds) {
+			i += 2
+			continue
+		}
+		merged := changeRun{dels: before.dels + runs[i].eqls + after.dels, adds: before.adds + runs[i].eqls + after.adds}
+		runs = append(runs[:i-1], append([]changeRun{merged}, runs[i+2:]...)...)
+		// the previous equality is now followed by larger changes
+		i = max(i-2, 1)
+	}
+	cleaned := make([]Edit[T], 0, len(edits))
+	x := 0
+	y := 0
+	for _, run := range runs {
+		for range run.eqls {
+			cleaned = append(cleaned, Edit[T]{Op: EqlOp, LeftIndex: x, RightIndex: y, Left: left[x], Right: right[y]})
+			x++
+			y++
+		}
+		for range run.dels {
+			cleaned = append(cleaned, Edit[T]{Op: DelOp, LeftIndex: x, RightIndex: -1, Left: left[x]})
+			x++
+		}
+		for range run.adds {
+			cleaned = append(cleaned, Edit[T]{Op: AddOp, LeftIndex: -1, RightIndex: y, Right: right[y]})
+			y++
+		}
+	}
+	return cleaned
+}
+
+// changedEdits creates the edits for the given changed flags. Within
+// each block of changes, the deleted elements precede the added ones.
+func changedEdits[T any](left []T, right []T, leftChanged []bool, rightChanged []bool) []Edit[T] {
+	edits := make([]Edit[T], 0, len(left)+len(right))
+	x := 0
+	y := 0
+	for x < len(left) || y < len(right) {
+		switch {
+		case x < len(left) && leftChanged[x]:
+			edits = append(edits, Edit[T]{Op: DelOp, LeftIndex: x, RightIndex: -1, Left: left[x]})
+			x++
+		case y < len(right) && rightChanged[y]:
+			edits = append(edits, Edit[T]{Op: AddOp, LeftIndex: -1, RightIndex: y, Right: right[y]})
+			y++
+		default:
+			edits = append(edits, Edit[T]{Op: EqlOp, LeftIndex: x, RightIndex: y, Left: left[x], Right: right[y]})
+			x++
+			y++
+		}
+	}
+	return edits
+}
+
+// indent heuristic parameters as tuned by git
+const (
+	indentMaxSliding            = 100
+	indentMax                   = 200
+	indentMaxBlanks             = 20
+	indentStartOfFilePenalty    = 1
+	indentEndOfFilePenalty      = 21
+	indentTotalBlankWeight      = -30
+	indentPostBlankWeight       = 6
+	indentRelativeIndent        = -4
+	indentRelativeIndentBlank   = 10
+	indentRelativeOutdent       = 24
+	indentRelativeOutdentBlank  = 17
+	indentRelativeDedent        = 23
+	indentRelativeDedentBlank   = 17
+	indentEffectiveIndentWeight = 60
+)
+
+// noIndent marks blank lines and positions outside the lines.
+const noIndent int = -1
+
+// bestShift determines the end position of the given group (currently
+// slid down as far as possible) with the lowest indent heuristic score.
+func (s *compactSide[T]) bestShift(g changeGroup, earliestEnd int) int {
+	size := g.end - g.start
+	shift := max(earliestEnd, g.end-size-1, g.end-indentMaxSliding)
+	bestShift := -1
+	var bestScore splitScore
+	for ; shift <= g.end; shift++ {
+		score := splitScore{}
+		score.add(s.measureSplit(shift))
+		score.add(s.measureSplit(shift - size))
+		if bestShift == -1 || score.compare(bestScore) <= 0 {
+			bestScore = score
+			bestShift = shift
+		}
+	}
+	return bestShift
+}
+
+// splitMeasurement describes the surrounding of a split position
+// (the position before the line at split).
+type splitMeasurement struct {
+	endOfFile  bool
+	indent     int
+	preBlank   int
+	preIndent  int
+	postBlank  int
+	postIndent int
+}
+
+func (s *compactSide[T]) measureSplit(split int) splitMeasurement {
+	m := splitMeasurement{indent: noIndent, preIndent: noIndent, postIndent: noIndent}
+	if split >= len(s.lines) {
+		m.endOfFile = true
+	} else {
+		m.indent = lineIndent(s.lines[split])
+	}
+	for i := split - 1; i >= 0; i-- {
+		m.preIndent = lineIndent(s.lines[i])
+		if m.preIndent != noIndent {
+			break
+		}
+		m.preBlank++
+		if m.preBlank == indentMaxBlanks {
+			m.preIndent = 0
+			break
+		}
+	}
+	for i := split + 1; i < len(s.lines); i++ {
+		m.postIndent = lineIndent(s.lines[i])
+		if m.postIndent != noIndent {
+			break
+		}
+		m.postBlank++
+		if m.postBlank == indentMaxBlanks {
+			m.postIndent = 0
+			break
+		}
+	}
+	return m
+}
+
+// lineIndent determines the indentation width of the given line
+// (-1 for blank lines).
+func lineIndent(line string) int {
+	indent := 0
+	for _, c := range []byte(line) {
+		switch c {
+		case ' ':
+			indent++
+		case '\t':
+			indent += tabWidth - indent%tabWidth
+		case '\n', '\r', '\f', '\v':
+		default:
+			return indent
+		}
+		if indent >= indentMax {
+			return indentMax
+		}
+	}
+	return noIndent
+}
+
+// splitScore rates a split position (lower is better).
+type splitScore struct {
+	effectiveIndent int
+	penalty         int
+}
+
+func (score *splitScore) add(m splitMeasurement) {
+	if m.preIndent == noIndent && m.preBlank == 0 {
+		score.penalty += indentStartOfFilePenalty
+	}
+	if m.endOfFile {
+		score.penalty += indentEndOfFilePenalty
+	}
+	postBlank := 0
+	if m.indent == noIndent {
+		postBlank = 1 + m.postBlank
+	}
+	totalBlank := m.preBlank + postBlank
+	score.penalty += indentTotalBlankWeight*totalBlank + indentPostBlankWeight*postBlank
+	indent := m.indent
+	if indent == noIndent {
+		indent = m.postIndent
+	}
+	anyBlanks := totalBlank != 0
+	score.effectiveIndent += indent
+	switch {
+	case indent == noIndent || m.preIndent == noIndent:
+	case indent > m.preIndent:
+		score.penalty += selectPenalty(anyBlanks, indentRelativeIndentBlank, indentRelativeIndent)
+	case indent == m.preIndent:
+	case m.postIndent != noIndent && m.postIndent > indent:
+		score.penalty += selectPenalty(anyBlanks, indentRelativeOutdentBlank, indentRelativeOutdent)
+	default:
+		score.penalty += selectPenalty(anyBlanks, indentRelativeDedentBlank, indentRelativeDedent)
+	}
+}
+
+func (score *splitScore) compare(other splitScore) int {
+	cmpIndents := 0
+	switch {
+	case score.effectiveIndent > other.effectiveIndent:
+		cmpIndents = 1
+	case score.effectiveIndent < other.effectiveIndent:
+		cmpIndents = -1
+	}
+	return indentEffectiveIndentWeight*cmpIndents + score.penalty - other.penalty
+}
+
+func selectPenalty(blank bool, blankPenalty int, penalty int) int {
+	if blank {
+		return blankPenalty
+	}
+	return penalty
+}
@@ -0,0 +1,97 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+// The expected outputs in testdata/cleanup have been generated using
+// (with the function context stripped from the hunk ranges):
+//
+//	git diff --no-index <name>_l.txt <name>_r.txt
+//	git diff --no-index --no-indent-heuristic <name>_l.txt <name>_r.txt
+func TestCleanup(t *testing.T) {
+	flags := map[string]diff.CleanupFlag{
+		"indent":  diff.IndentCleanup,
+		"compact": diff.CompactCleanup,
+	}
+	expectedFiles, err := filepath.Glob("testdata/cleanup/*.diff")
+	require.NoError(t, err)
+	require.NotEmpty(t, expectedFiles)
+	for _, expectedFile := range expectedFiles {
+		name := strings.TrimSuffix(expectedFile, ".diff")
+		t.Run(filepath.Base(name), func(t *testing.T) {
+			expected, err := os.ReadFile(expectedFile)
+			require.NoError(t, err)
+			base := name[:strings.LastIndex(name, "_")]
+			flag := flags[name[strings.LastIndex(name, "_")+1:]]
+			for _, algorithm := range []diff.Algorithm{diff.MyersAlgorithm, diff.PatienceAlgorithm, diff.HistogramAlgorithm} {
+				result, err := diff.DiffFiles(base+"_l.txt", base+"_r.txt", diff.WithAlgorithm(algorithm), diff.WithCleanup(flag))
+				require.NoError(t, err)
+				output := &strings.Builder{}
+				diff.NewPrinter(output, diff.WithAnsi(false), diff.WithUnifiedFormatter(diff.DefaultUnifiedContext)).Print(result)
+				require.Equal(t, testNormalizeHunks(testStripHeader(string(expected))), testNormalizeHunks(testStripHeader(output.String())))
+			}
+		})
+	}
+}
+
+func TestSemanticCleanup(t *testing.T) {
+	require.Equal(t, "-mouse+sofas", testCharDiffs(diff.DiffStrings("mouse", "sofas", diff.WithCleanup(diff.SemanticCleanup))))
+	require.Equal(t, "-m+s o-u+fa s-e", testCharDiffs(diff.DiffStrings("mouse", "sofas")))
+	require.Equal(t, " the -cat+dog  sat", testCharDiffs(diff.DiffStrings("the cat sat", "the dog sat", diff.WithCleanup(diff.SemanticCleanup))))
+	script := diff.DiffSlices([]int{1, 2, 3, 4, 5}, []int{6, 2, 7, 4, 8}, diff.WithCleanup(diff.SemanticCleanup))
+	require.Equal(t, []diff.Edit[int]{
+		{Op: diff.DelOp, LeftIndex: 0, RightIndex: -1, Left: 1},
+		{Op: diff.DelOp, LeftIndex: 1, RightIndex: -1, Left: 2},
+		{Op: diff.DelOp, LeftIndex: 2, RightIndex: -1, Left: 3},
+		{Op: diff.DelOp, LeftIndex: 3, RightIndex: -1, Left: 4},
+		{Op: diff.DelOp, LeftIndex: 4, RightIndex: -1, Left: 5},
+		{Op: diff.AddOp, LeftIndex: -1, RightIndex: 0, Right: 6},
+		{Op: diff.AddOp, LeftIndex: -1, RightIndex: 1, Right: 2},
+		{Op: diff.AddOp, LeftIndex: -1, RightIndex: 2, Right: 7},
+		{Op: diff.AddOp, LeftIndex: -1, RightIndex: 3, Right: 4},
+		{Op: diff.AddOp, LeftIndex: -1, RightIndex: 4, Right: 8},
+	}, script.Edits)
+}
+
+func TestCompactCleanupSlices(t *testing.T) {
+	script := diff.DiffFunc([]string{"a", "b", "x", "x", "c"}, []string{"A", "N", "b", "x", "c"}, strings.EqualFold, diff.WithCleanup(diff.IndentCleanup))
+	require.Equal(t, []diff.Edit[string]{
+		{Op: diff.EqlOp, LeftIndex: 0, RightIndex: 0, Left: "a", Right: "A"},
+		{Op: diff.AddOp, LeftIndex: -1, RightIndex: 1, Right: "N"},
+		{Op: diff.EqlOp, LeftIndex: 1, RightIndex: 2, Left: "b", Right: "b"},
+		{Op: diff.EqlOp, LeftIndex: 2, RightIndex: 3, Left: "x", Right: "x"},
+		{Op: diff.DelOp, LeftIndex: 3, RightIndex: -1, Left: "x"},
+		{Op: diff.EqlOp, LeftIndex: 4, RightIndex: 4, Left: "c", Right: "c"},
+	}, script.Edits)
+}
+
+func testCharDiffs(diffs []diff.CharDiff) string {
+	text := &strings.Builder{}
+	for i, d := range diffs {
+		if i == 0 || d.Op != diffs[i-1].Op {
+			switch d.Op {
+			case diff.AddOp:
+				text.WriteByte('+')
+			case diff.DelOp:
+				text.WriteByte('-')
+			default:
+				text.WriteByte(' ')
+			}
+		}
+		text.WriteString(d.Text)
+	}
+	return text.String()
+}
@@ -15,6 +15,18 @@ const ansiDel = "\x1b[31m"
 const ansiHdr = "\x1b[97m"
 const ansiLbl = "\x1b[96m"
 
+// Emphasis colors
+const ansiAddEmph = "\x1b[7;32m"
+const ansiDelEmph = "\x1b[7;31m"
+
+// Move colors
+const ansiAddMoved = "\x1b[1;36m"
+const ansiDelMoved = "\x1b[1;35m"
+const ansiAddMovedAlt = "\x1b[1;33m"
+const ansiDelMovedAlt = "\x1b[1;34m"
+const ansiMovedDim = "\x1b[2m"
+const ansiMovedAltDim = "\x1b[2;3m"
+
 // Reset
 const ansiRst = "\x1b[0m"
 
@@ -24,16 +36,42 @@ type Colors struct {
 	Del string
 	Hdr string
 	Lbl string
-	Rst string
+	// AddEmph and DelEmph are used to emphasize the changed parts of added
+	// and deleted lines, if enabled (see [WithHighlight]). If empty, changed
+	// lines are not refined.
+	AddEmph string
+	DelEmph string
+	// AddMoved and DelMoved are used to color moved lines (see [Result.DetectMoves]).
+	// The Alt variants are used for alternate blocks and the Dim variants for dimmed
+	// lines. If empty, moved lines are colored like added and deleted lines.
+	AddMoved       string
+	DelMoved       string
+	AddMovedAlt    string
+	DelMovedAlt    string
+	AddMovedDim    string
+	DelMovedDim    string
+	AddMovedAltDim string
+	DelMovedAltDim string
+	Rst            string
 }
 
 var noColors *Colors = &Colors{}
 
 var defaultColors *Colors = &Colors{
-	Eql: ansiEql,
-	Add: ansiAdd,
-	Del: ansiDel,
-	Hdr: ansiHdr,
-	Lbl: ansiLbl,
-	Rst: ansiRst,
+	Eql:            ansiEql,
+	Add:            ansiAdd,
+	Del:            ansiDel,
+	Hdr:            ansiHdr,
+	Lbl:            ansiLbl,
+	AddEmph:        ansiAddEmph,
+	DelEmph:        ansiDelEmph,
+	AddMoved:       ansiAddMoved,
+	DelMoved:       ansiDelMoved,
+	AddMovedAlt:    ansiAddMovedAlt,
+	DelMovedAlt:    ansiDelMovedAlt,
+	AddMovedDim:    ansiMovedDim,
+	DelMovedDim:    ansiMovedDim,
+	AddMovedAltDim: ansiMovedAltDim,
+	DelMovedAltDim: ansiMovedAltDim,
+	Rst:            ansiRst,
 }
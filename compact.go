@@ -0,0 +1,176 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+// compactSide contains the state of one side during compaction. The lines
+// are only used to determine the shift of a group and may be nil.
+type compactSide[T any] struct {
+	elements []T
+	equal    func(T, T) bool
+	lines    []string
+	changed  []bool
+}
+
+func (s *compactSide[T]) isChanged(index int) bool {
+	return index >= 0 && index < len(s.changed) && s.changed[index]
+}
+
+// changeGroup represents a (possibly empty) block of changed elements
+// [start, end) on one side.
+type changeGroup struct {
+	start int
+	end   int
+}
+
+func (s *compactSide[T]) firstGroup() changeGroup {
+	g := changeGroup{}
+	for s.isChanged(g.end) {
+		g.end++
+	}
+	return g
+}
+
+func (s *compactSide[T]) nextGroup(g *changeGroup) bool {
+	if g.end == len(s.changed) {
+		return false
+	}
+	g.start = g.end + 1
+	g.end = g.start
+	for s.isChanged(g.end) {
+		g.end++
+	}
+	return true
+}
+
+func (s *compactSide[T]) previousGroup(g *changeGroup) bool {
+	if g.start == 0 {
+		return false
+	}
+	g.end = g.start - 1
+	g.start = g.end
+	for s.isChanged(g.start - 1) {
+		g.start--
+	}
+	return true
+}
+
+func (s *compactSide[T]) slideDown(g *changeGroup) bool {
+	if g.end >= len(s.elements) || !s.equal(s.elements[g.start], s.elements[g.end]) {
+		return false
+	}
+	s.changed[g.start] = false
+	s.changed[g.end] = true
+	g.start++
+	g.end++
+	for s.isChanged(g.end) {
+		g.end++
+	}
+	return true
+}
+
+func (s *compactSide[T]) slideUp(g *changeGroup) bool {
+	if g.start == 0 || !s.equal(s.elements[g.start-1], s.elements[g.end-1]) {
+		return false
+	}
+	g.start--
+	g.end--
+	s.changed[g.start] = true
+	s.changed[g.end] = false
+	for s.isChanged(g.start - 1) {
+		g.start--
+	}
+	return true
+}
+
+// compactChanges slides the groups of changes of the given side (following
+// git's xdl_change_compact). The groups of the other side are not modified,
+// but tracked to keep both sides in sync. If a shift function is given, groups
+// not aligned with the other side are placed at the returned end position.
+func compactChanges[T any](side *compactSide[T], other *compactSide[T], shift func(*compactSide[T], changeGroup, int) int) {
+	g := side.firstGroup()
+	og := other.firstGroup()
+	for {
+		if g.end > g.start {
+			// slide up and down as far as possible (merging adjacent groups)
+			earliestEnd := 0
+			endMatchingOther := -1
+			for {
+				size := g.end - g.start
+				endMatchingOther = -1
+				for side.slideUp(&g) {
+					other.previousGroup(&og)
+				}
+				earliestEnd = g.end
+				if og.end > og.start {
+					endMatchingOther = g.end
+				}
+				for side.slideDown(&g) {
+					other.nextGroup(&og)
+					if og.end > og.start {
+						endMatchingOther = g.end
+					}
+				}
+				if size == g.end-g.start {
+					break
+				}
+			}
+			switch {
+			case g.end == earliestEnd:
+				// no sliding possible
+			case endMatchingOther != -1:
+				// align with the last possible group of the other side
+				for og.end == og.start {
+					side.slideUp(&g)
+					other.previousGroup(&og)
+				}
+			case shift != nil && side.lines != nil:
+				bestShift := shift(side, g, earliestEnd)
+				for g.end > bestShift {
+					side.slideUp(&g)
+					other.previousGroup(&og)
+				}
+			}
+		}
+		if !side.nextGroup(&g) {
+			break
+		}
+		other.nextGroup(&og)
+	}
+}
+
+// compactScript slides the changes of the given script on the interned
+// lines like git does for all of its diff output.
+func compactScript(s *script, a []int, b []int) {
+	if s.err != nil {
+		return
+	}
+	leftChanged := make([]bool, len(a))
+	rightChanged := make([]bool, len(b))
+	x := 0
+	y := 0
+	for _, op := range s.ops {
+		switch op {
+		case EqlOp:
+			x++
+			y++
+		case DelOp:
+			leftChanged[x] = true
+			x++
+		case AddOp:
+			rightChanged[y] = true
+			y++
+		}
+	}
+	leftSide := &compactSide[int]{elements: a, equal: equalComparable[int], changed: leftChanged}
+	rightSide := &compactSide[int]{elements: b, equal: equalComparable[int], changed: rightChanged}
+	compactChanges(leftSide, rightSide, nil)
+	compactChanges(rightSide, leftSide, nil)
+	s.ops = s.ops[:0]
+	for _, edit := range changedEdits(a, b, leftChanged, rightChanged) {
+		s.ops = append(s.ops, edit.Op)
+	}
+}
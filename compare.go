@@ -0,0 +1,171 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WithIgnoreCase ignores case differences while comparing lines
+// (like GNU diff's -i option).
+func WithIgnoreCase() DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.ignoreCase = true
+	})
+}
+
+// WithIgnoreSpaceChange ignores changes in the amount of white space while
+// comparing lines (like GNU diff's -b option).
+//
+// White space at line end is ignored and all other sequences of white space
+// are considered equal.
+func WithIgnoreSpaceChange() DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.ignoreSpaceChange = true
+	})
+}
+
+// WithIgnoreAllSpace ignores all white space while comparing lines
+// (like GNU diff's -w option).
+func WithIgnoreAllSpace() DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.ignoreAllSpace = true
+	})
+}
+
+// WithIgnoreBlankLines ignores changes consisting of blank lines only
+// (like GNU diff's -B option).
+//
+// The lines of such changes are marked as ignored (see [LineDiff.Ignored]) and
+// are not reported as hunks (see [Result.Hunks]), unless they are close to
+// other changes. Only empty lines are considered blank, unless white space is
+// ignored (see [WithIgnoreSpaceChange] and [WithIgnoreAllSpace]).
+func WithIgnoreBlankLines() DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.ignoreBlankLines = true
+	})
+}
+
+// WithStripTrailingCR strips the trailing carriage return of lines before
+// comparing them (like GNU diff's --strip-trailing-cr option).
+func WithStripTrailingCR() DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.stripTrailingCR = true
+	})
+}
+
+// WithLineEqual sets a custom function for comparing lines.
+//
+// The function is invoked with the lines as normalized by any other
+// comparison option (e.g. [WithLineKey]). As the lines can not be hashed
+// in this case, each line is compared with all distinct lines seen so far,
+// making the diff operation considerably slower for large inputs.
+func WithLineEqual(equal func(left string, right string) bool) DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.lineEqual = equal
+	})
+}
+
+func (d *Differ) normalizing() bool {
+	return d.ignoreCase || d.ignoreSpaceChange || d.ignoreAllSpace || d.stripTrailingCR || d.lineKey != nil
+}
+
+func (d *Differ) keys(lines []string) []string {
+	if !d.normalizing() {
+		return lines
+	}
+	keys := make([]string, len(lines))
+	for i, line := range lines {
+		keys[i] = d.key(line)
+	}
+	return keys
+}
+
+func (d *Differ) key(line string) string {
+	key := line
+	if d.stripTrailingCR {
+		key = stripTrailingCR(key)
+	}
+	if d.ignoreAllSpace {
+		key = removeSpace(key)
+	} else if d.ignoreSpaceChange {
+		key = collapseSpace(key)
+	}
+	if d.ignoreCase {
+		key = strings.ToLower(key)
+	}
+	if d.lineKey != nil {
+		key = d.lineKey(key)
+	}
+	return key
+}
+
+func stripTrailingCR(line string) string {
+	if strings.HasSuffix(line, "\r\n") {
+		return line[:len(line)-2] + "\n"
+	}
+	return strings.TrimSuffix(line, "\r")
+}
+
+func removeSpace(line string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, line)
+}
+
+func collapseSpace(line string) string {
+	collapsed := strings.Builder{}
+	collapsed.Grow(len(line))
+	space := false
+	for _, r := range strings.TrimRightFunc(line, unicode.IsSpace) {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			collapsed.WriteByte(' ')
+			space = false
+		}
+		collapsed.WriteRune(r)
+	}
+	return collapsed.String()
+}
+
+// isBlank determines whether the given line is blank in the sense
+// of [WithIgnoreBlankLines].
+func (d *Differ) isBlank(line string) bool {
+	if d.ignoreSpaceChange || d.ignoreAllSpace {
+		return strings.TrimSpace(line) == ""
+	}
+	if d.stripTrailingCR {
+		line = stripTrailingCR(line)
+	}
+	return line == "" || line == "\n"
+}
+
+// ignoreBlankChanges marks the lines of changes consisting of blank
+// lines only as ignored.
+func (d *Differ) ignoreBlankChanges(diffs []LineDiff) {
+	for i := 0; i < len(diffs); {
+		if diffs[i].Op == EqlOp {
+			i++
+			continue
+		}
+		blank := true
+		j := i
+		for ; j < len(diffs) && diffs[j].Op != EqlOp; j++ {
+			blank = blank && d.isBlank(diffs[j].Line)
+		}
+		for ; i < j; i++ {
+			diffs[i].Ignored = blank
+		}
+	}
+}
@@ -0,0 +1,103 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestCompareIgnoreCase(t *testing.T) {
+	left := []string{"Line\n", "other\n"}
+	right := []string{"LINE\n", "changed\n"}
+	testCompare(t, left, right, 1, diff.WithIgnoreCase())
+}
+
+func TestCompareIgnoreSpaceChange(t *testing.T) {
+	left := []string{"a  b\n", " c\n", "d\n"}
+	right := []string{"a\tb   \n", "c\n", "d\n"}
+	testCompare(t, left, right, 2, diff.WithIgnoreSpaceChange())
+}
+
+func TestCompareIgnoreAllSpace(t *testing.T) {
+	left := []string{"a  b\n", " c\n", "d\n"}
+	right := []string{"ab\n", "c \n", "d e\n"}
+	testCompare(t, left, right, 2, diff.WithIgnoreAllSpace())
+}
+
+func TestCompareStripTrailingCR(t *testing.T) {
+	left := []string{"a\r\n", "b\r\n", "c\r\n"}
+	right := []string{"a\n", "b\n", "d\n"}
+	testCompare(t, left, right, 2, diff.WithStripTrailingCR())
+}
+
+func TestCompareIgnoreBlankLines(t *testing.T) {
+	left := []string{"a\n", "\n", "b\n"}
+	right := []string{"a\n", "b\n"}
+	result := diff.DiffLines(left, right, diff.WithIgnoreBlankLines())
+	require.Equal(t, []diff.LineDiff{
+		{Op: diff.EqlOp, Line: "a\n", LeftNumber: 1, RightNumber: 1},
+		{Op: diff.DelOp, Line: "\n", LeftNumber: 2, Ignored: true},
+		{Op: diff.EqlOp, Line: "b\n", LeftNumber: 3, RightNumber: 2},
+	}, result.Diffs)
+	require.Empty(t, slices.Collect(result.Hunks(diff.DefaultUnifiedContext)))
+	testRequireSides(t, left, right, result)
+}
+
+// The expected outputs have been generated using GNU diff's -B option.
+func TestCompareIgnoreBlankLinesHunks(t *testing.T) {
+	left := []string{"1\n", "2\n", "3\n", "4\n", "5\n", "6\n", "7\n", "8\n", "9\n"}
+	right := []string{"1\n", "\n", "2\n", "3\n", "4\n", "X\n", "6\n", "7\n", "8\n", "9\n", "\n"}
+	result := diff.DiffLines(left, right, diff.WithIgnoreBlankLines())
+	unified := &strings.Builder{}
+	diff.NewPrinter(unified, diff.WithAnsi(false), diff.WithUnifiedFormatter(diff.DefaultUnifiedContext)).Print(result)
+	require.Equal(t, "@@ -1,8 +1,9 @@\n 1\n+\n 2\n 3\n 4\n-5\n+X\n 6\n 7\n 8\n", testStripHeader(unified.String()))
+	normal := &strings.Builder{}
+	diff.NewPrinter(normal, diff.WithAnsi(false), diff.WithNormalFormatter()).Print(result)
+	require.Equal(t, "5c6\n< 5\n---\n> X\n", normal.String())
+}
+
+func TestCompareIgnoreBlankLinesSpace(t *testing.T) {
+	left := []string{"1\n", "2\n", "  \n", "3\n"}
+	right := []string{"1\n", "2\n", "3\n"}
+	result := diff.DiffLines(left, right, diff.WithIgnoreBlankLines())
+	require.Len(t, slices.Collect(result.Hunks(0)), 1)
+	result = diff.DiffLines(left, right, diff.WithIgnoreBlankLines(), diff.WithIgnoreSpaceChange())
+	require.Empty(t, slices.Collect(result.Hunks(0)))
+	result = diff.DiffLines(left, right, diff.WithIgnoreBlankLines(), diff.WithIgnoreAllSpace())
+	require.Empty(t, slices.Collect(result.Hunks(0)))
+}
+
+func TestCompareLineEqual(t *testing.T) {
+	left := []string{"1: a\n", "2: b\n", "3: c\n"}
+	right := []string{"4: a\n", "5: c\n"}
+	equal := func(a string, b string) bool {
+		_, restA, _ := strings.Cut(a, ":")
+		_, restB, _ := strings.Cut(b, ":")
+		return restA == restB
+	}
+	for _, algorithm := range []diff.Algorithm{diff.MyersAlgorithm, diff.PatienceAlgorithm, diff.HistogramAlgorithm} {
+		testCompare(t, left, right, 2, diff.WithLineEqual(equal), diff.WithAlgorithm(algorithm))
+	}
+}
+
+func testCompare(t *testing.T, left []string, right []string, eql int, opts ...diff.DiffOption) *diff.Result {
+	strict := diff.DiffLines(left, right)
+	require.Less(t, testEqlCount(strict), eql)
+	result := diff.DiffLines(left, right, opts...)
+	require.Equal(t, eql, testEqlCount(result))
+	testRequireSides(t, left, right, result)
+	return result
+}
+
+func testEqlCount(result *diff.Result) int {
+	return len(result.Diffs) - testEditCount(result)
+}
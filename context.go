@@ -0,0 +1,119 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"fmt"
+)
+
+// DefaultContextContext defines the default context size (3) for context diff output.
+const DefaultContextContext int = 3
+
+// WithContextFormatter wraps WithFormatter to format the ouput in context diff format
+// (like diff -c).
+func WithContextFormatter(context int) PrinterOption {
+	checkedContext := context
+	if checkedContext < 0 {
+		checkedContext = DefaultContextContext
+	}
+	return PrinterOptionFunc(func(p *Printer) {
+		p.formatter = &contextFormatter{Context: checkedContext}
+	})
+}
+
+type contextFormatter struct {
+	Context int
+}
+
+func (f *contextFormatter) Format(p *Printer, r *Result) {
+	f.formatHeader(p, r)
+	colors := p.Colors()
+	for hunk := range r.Hunks(f.Context) {
+		fmt.Fprintf(p, "%s***************%s\n", colors.Lbl, colors.Rst)
+		changed := f.changedDiffs(hunk)
+		fmt.Fprintf(p, "%s*** %s ****%s\n", colors.Lbl, formatLineRange(hunk.LeftStart, hunk.LeftLength), colors.Rst)
+		// like diff -c, the lines of a side are omitted, if the side contains no changes
+		if f.countOp(hunk, DelOp) > 0 {
+			for index, diff := range hunk.Diffs {
+				if diff.Op != AddOp {
+					f.formatDiff(p, diff, diff.Line, changed[index])
+				}
+			}
+		}
+		fmt.Fprintf(p, "%s--- %s ----%s\n", colors.Lbl, formatLineRange(hunk.RightStart, hunk.RightLength), colors.Rst)
+		if f.countOp(hunk, AddOp) > 0 {
+			for index, diff := range hunk.Diffs {
+				if diff.Op != DelOp {
+					f.formatDiff(p, diff, rightLine(diff), changed[index])
+				}
+			}
+		}
+	}
+}
+
+func (f *contextFormatter) formatHeader(p *Printer, r *Result) {
+	colors := p.Colors()
+	fmt.Fprintf(p, "%s*** %s\t%s%s\n", colors.Hdr, r.LeftName, modificationTime(r.LeftName), colors.Rst)
+	fmt.Fprintf(p, "%s--- %s\t%s%s\n", colors.Hdr, r.RightName, modificationTime(r.RightName), colors.Rst)
+}
+
+// changedDiffs marks the diffs, which are part of a block
+// containing deleted as well as added lines.
+func (f *contextFormatter) changedDiffs(hunk Hunk) []bool {
+	changed := make([]bool, len(hunk.Diffs))
+	start := 0
+	for start < len(hunk.Diffs) {
+		if hunk.Diffs[start].Op == EqlOp {
+			start++
+			continue
+		}
+		end := start
+		adds, dels := 0, 0
+		for end < len(hunk.Diffs) && hunk.Diffs[end].Op != EqlOp {
+			if hunk.Diffs[end].Op == AddOp {
+				adds++
+			} else {
+				dels++
+			}
+			end++
+		}
+		for index := start; index < end; index++ {
+			changed[index] = adds > 0 && dels > 0
+		}
+		start = end
+	}
+	return changed
+}
+
+func (f *contextFormatter) countOp(hunk Hunk, op Op) int {
+	count := 0
+	for _, diff := range hunk.Diffs {
+		if diff.Op == op {
+			count++
+		}
+	}
+	return count
+}
+
+func (f *contextFormatter) formatDiff(p *Printer, diff LineDiff, line string, changed bool) {
+	var op string
+	set, rst := p.OpColor(diff.Op)
+	switch {
+	case diff.Op == EqlOp:
+		op = " "
+	case changed:
+		op = "!"
+	case diff.Op == AddOp:
+		op = "+"
+	case diff.Op == DelOp:
+		op = "-"
+	}
+	fmt.Fprintf(p, "%s%s %s%s", set, op, line, rst)
+	if lineEnd(line) != "" {
+		fmt.Fprint(p, "\n"+noNewlineMarker)
+	}
+}
@@ -0,0 +1,54 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+const expectedContextDiffPlain string = "*** ./l.txt\t0001-01-01 00:00:00 +0000 UTC\n--- ./r.txt\t0001-01-01 00:00:00 +0000 UTC\n***************\n*** 1,3 ****\n--- 1,13 ----\n+ 0\n+ 1\n+ 2\n+ 3\n+ 4\n+ 5\n+ 6\n+ 7\n+ 8\n+ 9\n  a\n  b\n  c\n***************\n*** 24,29 ****\n  x\n  y\n  z\n- ä\n- ö\n- ü\n--- 34,36 ----\n"
+
+func TestContext(t *testing.T) {
+	result, err := diff.DiffFiles(leftFileName, rightFileName)
+	require.NoError(t, err)
+
+	// set names to non-existing file to force mtime 0
+	result.LeftName = "./" + diff.DefaultLeftName
+	result.RightName = "./" + diff.DefaultRightName
+
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithContextFormatter(diff.DefaultContextContext)).Print(result)
+	require.Equal(t, expectedContextDiffPlain, output.String())
+}
+
+func TestContextChanged(t *testing.T) {
+	left := []string{"a\n", "b\n", "c\n"}
+	right := []string{"a\n", "X\n", "c\n", "d"}
+	result := diff.DiffLines(left, right, diff.WithLeftName("./l.txt"), diff.WithRightName("./r.txt"))
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithContextFormatter(diff.DefaultContextContext)).Print(result)
+	require.Equal(t, "***************\n*** 1,3 ****\n  a\n! b\n  c\n--- 1,4 ----\n  a\n! X\n  c\n+ d\n\\ No newline at end of file\n", testStripContextHeader(output.String()))
+	output.Reset()
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithContextFormatter(0)).Print(result)
+	require.Equal(t, "***************\n*** 2 ****\n! b\n--- 2 ----\n! X\n***************\n*** 3 ****\n--- 4 ----\n+ d\n\\ No newline at end of file\n", testStripContextHeader(output.String()))
+}
+
+func TestContextAnsi(t *testing.T) {
+	result := diff.DiffLines([]string{"a\n"}, []string{"b\n", "a\n"}, diff.WithLeftName("./l.txt"), diff.WithRightName("./r.txt"))
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithContextFormatter(0)).Print(result)
+	require.Equal(t, "\x1b[96m***************\x1b[0m\n\x1b[96m*** 0 ****\x1b[0m\n\x1b[96m--- 1 ----\x1b[0m\n\x1b[32m+ b\n\x1b[0m", testStripContextHeader(output.String()))
+}
+
+func testStripContextHeader(output string) string {
+	lines := strings.SplitAfterN(output, "\n", 3)
+	return lines[2]
+}
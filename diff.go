@@ -9,10 +9,11 @@ package diff
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"os"
 	"slices"
+	"strings"
 )
 
 // Op defines the diff operation associated with a specific line.
@@ -44,8 +45,24 @@ func (op Op) String() string {
 type LineDiff struct {
 	// Op indicates the diff operation associated with this line.
 	Op Op
-	// Line contains the actual line.
+	// Line contains the actual line (the left one in case of EqlOp).
 	Line string
+	// RightLine contains the right line in case of EqlOp, if it differs
+	// from the left one (see comparison options like [WithIgnoreCase]).
+	// It is empty otherwise.
+	RightLine string
+	// LeftNumber contains the line number (1-based) of the left line
+	// (0 in case of AddOp).
+	LeftNumber int
+	// RightNumber contains the line number (1-based) of the right line
+	// (0 in case of DelOp).
+	RightNumber int
+	// Move contains the move information of an added or deleted line
+	// (see [Result.DetectMoves]).
+	Move LineMove
+	// Ignored indicates, the added or deleted line belongs to a change, which
+	// is ignored due to the comparison options (see [WithIgnoreBlankLines]).
+	Ignored bool
 }
 
 // DefaultLeftName is used to name the left side of a diff
@@ -66,194 +83,194 @@ type Result struct {
 	RightName string
 	// Diffs contains for all compared lines the diff result.
 	Diffs []LineDiff
+	// LeftNoNewline indicates, the last left line
+	// is not terminated by a newline.
+	LeftNoNewline bool
+	// RightNoNewline indicates, the last right line
+	// is not terminated by a newline.
+	RightNoNewline bool
+	// Heuristic indicates, the diff result is not minimal, because
+	// the edit cost limit has been exceeded (see [WithMaxEditCost]).
+	Heuristic bool
 }
 
 // Print prints the diff result to the given writer.
 func (r *Result) Print(w io.Writer) {
 	for _, diff := range r.Diffs {
-		fmt.Fprintf(w, "%s %s", diff.Op, diff.Line)
+		fmt.Fprintf(w, "%s %s%s", diff.Op, diff.Line, lineEnd(diff.Line))
 	}
 }
 
-func (r *Result) reverse() {
-	slices.Reverse(r.Diffs)
+// Left reconstructs the left lines from the diff result.
+func (r *Result) Left() []string {
+	lines := make([]string, 0, len(r.Diffs))
+	for _, diff := range r.Diffs {
+		if diff.Op != AddOp {
+			lines = append(lines, diff.Line)
+		}
+	}
+	return lines
+}
+
+// Right reconstructs the right lines from the diff result.
+func (r *Result) Right() []string {
+	lines := make([]string, 0, len(r.Diffs))
+	for _, diff := range r.Diffs {
+		if diff.Op != DelOp {
+			lines = append(lines, rightLine(diff))
+		}
+	}
+	return lines
 }
 
-func (r *Result) keepLine(line string) {
-	r.Diffs = append(r.Diffs, LineDiff{Op: EqlOp, Line: line})
+// LeftReader provides the reconstructed left content of the diff result.
+func (r *Result) LeftReader() io.Reader {
+	return strings.NewReader(strings.Join(r.Left(), ""))
 }
 
-func (r *Result) addLine(line string) {
-	r.Diffs = append(r.Diffs, LineDiff{Op: AddOp, Line: line})
+// RightReader provides the reconstructed right content of the diff result.
+func (r *Result) RightReader() io.Reader {
+	return strings.NewReader(strings.Join(r.Right(), ""))
 }
 
-func (r *Result) addLines(lines []string) {
-	for _, line := range lines {
-		r.addLine(line)
+// rightLine returns the right line of the given diff.
+func rightLine(diff LineDiff) string {
+	if diff.Op == EqlOp && diff.RightLine != "" {
+		return diff.RightLine
 	}
+	return diff.Line
 }
 
-func (r *Result) deleteLine(line string) {
-	r.Diffs = append(r.Diffs, LineDiff{Op: DelOp, Line: line})
-}
+// noNewlineMarker is printed after lines not terminated by a newline.
+const noNewlineMarker = "\\ No newline at end of file\n"
 
-func (r *Result) deleteLines(lines []string) {
-	for _, line := range lines {
-		r.deleteLine(line)
+// lineEnd returns the newline to print after the given line,
+// in case the line is not terminated by a newline.
+func lineEnd(line string) string {
+	if strings.HasSuffix(line, "\n") {
+		return ""
 	}
+	return "\n"
 }
 
-// DiffFiles runs a diff operation on the two given file names.
-func DiffFiles(leftName string, rightName string) (*Result, error) {
-	left, err := os.Open(leftName)
-	if err != nil {
-		return nil, err
-	}
-	defer left.Close()
-	right, err := os.Open(rightName)
-	if err != nil {
-		return nil, err
+func hasNoNewline(lines []string) bool {
+	return len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n")
+}
+
+// completeLastLine terminates the last line by a newline (if not yet done),
+// without modifying the given lines.
+func completeLastLine(lines []string) []string {
+	if !hasNoNewline(lines) {
+		return lines
 	}
-	defer right.Close()
-	differ, err := differFromReaders(left, leftName, right, rightName)
-	if err != nil {
-		return nil, err
+	completed := slices.Clone(lines)
+	completed[len(completed)-1] += "\n"
+	return completed
+}
+
+func (r *Result) keepLine(leftLine string, rightLine string, leftNumber int, rightNumber int) {
+	diff := LineDiff{Op: EqlOp, Line: leftLine, LeftNumber: leftNumber, RightNumber: rightNumber}
+	if leftLine != rightLine {
+		diff.RightLine = rightLine
 	}
-	return differ.run(), nil
+	r.Diffs = append(r.Diffs, diff)
 }
 
-// DiffLines runs a diff operation on the two given string arrays.
-func DiffLines(left []string, right []string) *Result {
-	return differFromLines(left, DefaultLeftName, right, DefaultRightName).run()
+func (r *Result) addLine(line string, rightNumber int) {
+	r.Diffs = append(r.Diffs, LineDiff{Op: AddOp, Line: line, RightNumber: rightNumber})
 }
 
-// Diff runs a diff operation on the two given reader's contents.
-func Diff(left io.Reader, right io.Reader) (*Result, error) {
-	differ, err := differFromReaders(left, DefaultLeftName, right, DefaultRightName)
-	if err != nil {
-		return nil, err
-	}
-	return differ.run(), nil
+func (r *Result) deleteLine(line string, leftNumber int) {
+	r.Diffs = append(r.Diffs, LineDiff{Op: DelOp, Line: line, LeftNumber: leftNumber})
 }
 
-type differ struct {
-	Left      []string
-	LeftName  string
-	Right     []string
-	RightName string
+// DiffFiles runs a diff operation on the two given file names
+// using the given diff options.
+func DiffFiles(leftName string, rightName string, opts ...DiffOption) (*Result, error) {
+	return NewDiffer(opts...).DiffFiles(leftName, rightName)
 }
 
-func differFromLines(left []string, leftName string, right []string, rightName string) *differ {
-	return &differ{
-		Left:      left,
-		LeftName:  leftName,
-		Right:     right,
-		RightName: rightName,
-	}
+// DiffFilesContext runs a diff operation on the two given file names
+// using the given diff options and context.
+func DiffFilesContext(ctx context.Context, leftName string, rightName string, opts ...DiffOption) (*Result, error) {
+	return NewDiffer(opts...).DiffFilesContext(ctx, leftName, rightName)
 }
 
-func differFromReaders(left io.Reader, leftName string, right io.Reader, rightName string) (*differ, error) {
-	leftLines, err := readLines(left)
-	if err != nil {
-		return nil, err
-	}
-	rightLines, err := readLines(right)
-	if err != nil {
-		return nil, err
-	}
-	return differFromLines(leftLines, leftName, rightLines, rightName), nil
+// DiffLines runs a diff operation on the two given string arrays
+// using the given diff options.
+func DiffLines(left []string, right []string, opts ...DiffOption) *Result {
+	return NewDiffer(opts...).DiffLines(left, right)
 }
 
-func (p *differ) run() *Result {
-	l := len(p.Left)
-	r := len(p.Right)
-	max := l + r
-	result := &Result{
-		LeftName:  p.LeftName,
-		RightName: p.RightName,
-		Diffs:     make([]LineDiff, 0, max),
-	}
-	if p.runFast(result, l, r, max) {
-		return result
+// DiffLinesContext runs a diff operation on the two given string arrays
+// using the given diff options and context.
+func DiffLinesContext(ctx context.Context, left []string, right []string, opts ...DiffOption) (*Result, error) {
+	return NewDiffer(opts...).DiffLinesContext(ctx, left, right)
+}
+
+// Diff runs a diff operation on the two given reader's contents
+// using the given diff options.
+func Diff(left io.Reader, right io.Reader, opts ...DiffOption) (*Result, error) {
+	return NewDiffer(opts...).Diff(left, right)
+}
+
+// DiffContext runs a diff operation on the two given reader's contents
+// using the given diff options and context.
+func DiffContext(ctx context.Context, left io.Reader, right io.Reader, opts ...DiffOption) (*Result, error) {
+	return NewDiffer(opts...).DiffContext(ctx, left, right)
+}
+
+// script collects the edit operations determined by a diff algorithm
+// as well as the parameters and the state of the running diff operation.
+type script struct {
+	ops             []Op
+	linearThreshold int
+	maxCost         int
+	ctx             context.Context
+	done            <-chan struct{}
+	heuristic       bool
+	err             error
+}
+
+func newScript(ctx context.Context, linearThreshold int, maxCost int) *script {
+	return &script{
+		linearThreshold: linearThreshold,
+		maxCost:         maxCost,
+		ctx:             ctx,
+		done:            ctx.Done(),
 	}
-	p.runFull(result, l, r, max)
-	return result
 }
 
-func (p *differ) runFast(result *Result, l int, r int, max int) bool {
-	if max == 0 {
+// interrupted checks whether the diff operation has been canceled.
+func (s *script) interrupted() bool {
+	if s.err != nil {
 		return true
 	}
-	if l == 0 {
-		result.addLines(p.Right)
+	select {
+	case <-s.done:
+		s.err = s.ctx.Err()
 		return true
+	default:
+		return false
 	}
-	if r == 0 {
-		result.deleteLines(p.Left)
-		return true
+}
+
+func (s *script) keep(n int) {
+	for range n {
+		s.ops = append(s.ops, EqlOp)
 	}
-	return false
 }
 
-func (p *differ) runFull(result *Result, l int, r int, max int) {
-	v := make([]int, 2*max+1)
-	trace := make([][]int, 0, max)
-	for d := 0; d <= max; d++ {
-		dv := make([]int, len(v))
-		copy(dv, v)
-		trace = append(trace, dv)
-		for k := -d; k <= d; k += 2 {
-			var x int
-			if k == -d || (k != d && v[max+k-1] < v[max+k+1]) {
-				x = v[max+k+1]
-			} else {
-				x = v[max+k-1] + 1
-			}
-			y := x - k
-			for x < l && y < r && p.Left[x] == p.Right[y] {
-				x++
-				y++
-			}
-			v[max+k] = x
-			if x >= l && y >= r {
-				p.runFullBacktrack(result, trace, l, r, max)
-				return
-			}
-		}
+func (s *script) add(n int) {
+	for range n {
+		s.ops = append(s.ops, AddOp)
 	}
-	panic("unexpected")
 }
 
-func (p *differ) runFullBacktrack(result *Result, trace [][]int, l int, r int, max int) {
-	x := l
-	y := r
-	for d := len(trace) - 1; d >= 0; d-- {
-		v := trace[d]
-		k := x - y
-		var prevK int
-		if k == -d || (k != d && v[max+k-1] < v[max+k+1]) {
-			prevK = k + 1
-		} else {
-			prevK = k - 1
-		}
-		prevX := v[max+prevK]
-		prevY := prevX - prevK
-		for x > prevX && y > prevY {
-			x--
-			y--
-			result.keepLine(p.Left[x])
-		}
-		if d > 0 {
-			if prevX < x {
-				x--
-				result.deleteLine(p.Left[x])
-			} else {
-				y--
-				result.addLine(p.Right[y])
-			}
-		}
+func (s *script) delete(n int) {
+	for range n {
+		s.ops = append(s.ops, DelOp)
 	}
-	result.reverse()
 }
 
 func readLines(r io.Reader) ([]string, error) {
@@ -262,6 +279,10 @@ func readLines(r io.Reader) ([]string, error) {
 	for {
 		line, err := buf.ReadString('\n')
 		if err == io.EOF {
+			// keep last line even if it is not terminated by a newline
+			if line != "" {
+				lines = append(lines, line)
+			}
 			break
 		} else if err != nil {
 			return nil, err
@@ -0,0 +1,136 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"fmt"
+)
+
+// WithDiff3Formatter wraps WithMergeFormatter to format the merge output
+// in diff3 normal format (like GNU diff3).
+//
+// Our version is printed as file 1, the base version as file 2 and their
+// version as file 3.
+func WithDiff3Formatter() PrinterOption {
+	return PrinterOptionFunc(func(p *Printer) {
+		p.mergeFormatter = &diff3Formatter{}
+	})
+}
+
+// WithDiff3MergeFormatter wraps WithMergeFormatter to format the merge output
+// in diff3 merge format (like GNU diff3 -m).
+//
+// All changes are merged into our version. Conflicts as well as identical
+// changes in our and their version are bracketed.
+func WithDiff3MergeFormatter() PrinterOption {
+	return PrinterOptionFunc(func(p *Printer) {
+		p.mergeFormatter = &diff3Formatter{Merge: true}
+	})
+}
+
+type diff3Formatter struct {
+	Merge bool
+}
+
+func (f *diff3Formatter) FormatMerge(p *Printer, r *MergeResult) {
+	if f.Merge {
+		f.formatMerge(p, r)
+	} else {
+		f.formatNormal(p, r)
+	}
+}
+
+func (f *diff3Formatter) formatNormal(p *Printer, r *MergeResult) {
+	for _, chunk := range r.Chunks {
+		var header string
+		// the file, whose lines are omitted, because they are equal to the ones of another file
+		omit := 0
+		switch chunk.Kind {
+		case UnchangedChunk:
+			continue
+		case OursChunk:
+			header, omit = "====1", 2
+		case BothChunk:
+			header, omit = "====2", 1
+		case TheirsChunk:
+			header, omit = "====3", 1
+		case ConflictChunk:
+			header = "===="
+		}
+		colors := p.Colors()
+		fmt.Fprintf(p, "%s%s%s\n", colors.Hdr, header, colors.Rst)
+		f.formatNormalLines(p, 1, chunk.OursStart, chunk.Ours, omit, colors.Add)
+		// like GNU diff3, the base lines are printed last, if they are the odd ones
+		if chunk.Kind == BothChunk {
+			f.formatNormalLines(p, 3, chunk.TheirsStart, chunk.Theirs, omit, colors.Add)
+			f.formatNormalLines(p, 2, chunk.BaseStart, chunk.Base, omit, colors.Del)
+		} else {
+			f.formatNormalLines(p, 2, chunk.BaseStart, chunk.Base, omit, colors.Del)
+			f.formatNormalLines(p, 3, chunk.TheirsStart, chunk.Theirs, omit, colors.Add)
+		}
+	}
+}
+
+func (f *diff3Formatter) formatNormalLines(p *Printer, file int, start int, lines []string, omit int, set string) {
+	colors := p.Colors()
+	switch len(lines) {
+	case 0:
+		fmt.Fprintf(p, "%s%d:%da%s\n", colors.Lbl, file, start, colors.Rst)
+	case 1:
+		fmt.Fprintf(p, "%s%d:%dc%s\n", colors.Lbl, file, start, colors.Rst)
+	default:
+		fmt.Fprintf(p, "%s%d:%d,%dc%s\n", colors.Lbl, file, start, start+len(lines)-1, colors.Rst)
+	}
+	if file == omit {
+		return
+	}
+	for _, line := range lines {
+		fmt.Fprintf(p, "%s  %s%s", set, line, colors.Rst)
+		if lineEnd(line) != "" {
+			fmt.Fprint(p, "\n"+noNewlineMarker)
+		}
+	}
+}
+
+func (f *diff3Formatter) formatMerge(p *Printer, r *MergeResult) {
+	colors := p.Colors()
+	for _, chunk := range r.Chunks {
+		switch chunk.Kind {
+		case UnchangedChunk, OursChunk:
+			f.formatMergeLines(p, chunk.Ours, colors.Eql, false)
+		case TheirsChunk:
+			f.formatMergeLines(p, chunk.Theirs, colors.Eql, false)
+		case BothChunk:
+			// like GNU diff3, identical changes are bracketed against the base lines
+			f.formatMergeMarker(p, conflictOursMarker, r.BaseLabel)
+			f.formatMergeLines(p, chunk.Base, colors.Del, true)
+			f.formatMergeMarker(p, conflictSepMarker, "")
+			f.formatMergeLines(p, chunk.Theirs, colors.Add, true)
+			f.formatMergeMarker(p, conflictTheirsMarker, r.TheirsLabel)
+		case ConflictChunk:
+			f.formatMergeMarker(p, conflictOursMarker, r.OursLabel)
+			f.formatMergeLines(p, chunk.Ours, colors.Add, true)
+			f.formatMergeMarker(p, conflictBaseMarker, r.BaseLabel)
+			f.formatMergeLines(p, chunk.Base, colors.Del, true)
+			f.formatMergeMarker(p, conflictSepMarker, "")
+			f.formatMergeLines(p, chunk.Theirs, colors.Add, true)
+			f.formatMergeMarker(p, conflictTheirsMarker, r.TheirsLabel)
+		}
+	}
+}
+
+func (f *diff3Formatter) formatMergeMarker(p *Printer, marker string, label string) {
+	colors := p.Colors()
+	fmt.Fprintf(p, "%s%s%s", colors.Lbl, conflictMarker(marker, label), colors.Rst)
+}
+
+func (f *diff3Formatter) formatMergeLines(p *Printer, lines []string, set string, followed bool) {
+	colors := p.Colors()
+	for _, line := range terminateLines(lines, followed) {
+		fmt.Fprintf(p, "%s%s%s", set, line, colors.Rst)
+	}
+}
@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+const diff3BaseName string = "testdata/diff3/base.txt"
+const diff3OursName string = "testdata/diff3/ours.txt"
+const diff3TheirsName string = "testdata/diff3/theirs.txt"
+
+func TestDiff3Formatter(t *testing.T) {
+	testDiff3Formatter(t, "testdata/diff3/normal.diff3", diff.WithDiff3Formatter())
+}
+
+func TestDiff3MergeFormatter(t *testing.T) {
+	testDiff3Formatter(t, "testdata/diff3/merge.diff3", diff.WithDiff3MergeFormatter())
+}
+
+func TestDiff3FormatterNoNewline(t *testing.T) {
+	result := diff.MergeLines([]string{"a\n", "b"}, []string{"a\n", "c"}, []string{"a\n", "d"})
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithDiff3Formatter()).PrintMerge(result)
+	require.Equal(t, "====\n1:2c\n  c\n\\ No newline at end of file\n2:2c\n  b\n\\ No newline at end of file\n3:2c\n  d\n\\ No newline at end of file\n", output.String())
+	output.Reset()
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithDiff3MergeFormatter()).PrintMerge(result)
+	require.Equal(t, "a\n<<<<<<< ours\nc\n||||||| base\nb\n=======\nd\n>>>>>>> theirs\n", output.String())
+}
+
+func TestDiff3FormatterAnsi(t *testing.T) {
+	result := diff.MergeLines([]string{"a\n"}, []string{"b\n"}, []string{"a\n"})
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithDiff3Formatter()).PrintMerge(result)
+	require.Equal(t, "\x1b[97m====1\x1b[0m\n\x1b[96m1:1c\x1b[0m\n\x1b[32m  b\n\x1b[0m\x1b[96m2:1c\x1b[0m\n\x1b[96m3:1c\x1b[0m\n\x1b[32m  a\n\x1b[0m", output.String())
+}
+
+func TestDefaultMergePrinter(t *testing.T) {
+	result, err := diff.MergeFiles(diff3BaseName, diff3OursName, diff3TheirsName)
+	require.NoError(t, err)
+	output := &strings.Builder{}
+	diff.NewPrinter(output).PrintMerge(result)
+	require.Equal(t, strings.Join(result.Lines, ""), output.String())
+}
+
+func testDiff3Formatter(t *testing.T, expectedName string, opt diff.PrinterOption) {
+	result, err := diff.MergeFiles(diff3BaseName, diff3OursName, diff3TheirsName, diff.WithOursLabel("ours.txt"), diff.WithBaseLabel("base.txt"), diff.WithTheirsLabel("theirs.txt"))
+	require.NoError(t, err)
+	expected, err := os.ReadFile(expectedName)
+	require.NoError(t, err)
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), opt).PrintMerge(result)
+	require.Equal(t, string(expected), output.String())
+}
@@ -7,6 +7,9 @@
 package diff_test
 
 import (
+	"fmt"
+	"io"
+	"math/rand/v2"
 	"os"
 	"slices"
 	"strings"
@@ -45,6 +48,184 @@ func TestDiff(t *testing.T) {
 	require.Len(t, result.Diffs, 39)
 }
 
+func TestDiffNoNewline(t *testing.T) {
+	result, err := diff.Diff(strings.NewReader("a\nb"), strings.NewReader("a\nb\n"))
+	require.NoError(t, err)
+	require.Equal(t, []diff.LineDiff{
+		{Op: diff.EqlOp, Line: "a\n", LeftNumber: 1, RightNumber: 1},
+		{Op: diff.DelOp, Line: "b", LeftNumber: 2},
+		{Op: diff.AddOp, Line: "b\n", RightNumber: 2},
+	}, result.Diffs)
+	require.True(t, result.LeftNoNewline)
+	require.False(t, result.RightNoNewline)
+	output := &strings.Builder{}
+	result.Print(output)
+	require.Equal(t, "= a\n> b\n< b\n", output.String())
+}
+
+func TestDiffLinear(t *testing.T) {
+	fullResult, err := diff.DiffFiles(leftFileName, rightFileName)
+	require.NoError(t, err)
+	linearResult, err := diff.DiffFiles(leftFileName, rightFileName, diff.WithLinearSpaceThreshold(0))
+	require.NoError(t, err)
+	require.Equal(t, fullResult.Diffs, linearResult.Diffs)
+}
+
+func TestDiffLinearRandom(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+	for range 200 {
+		left := testRandomLines(random, random.IntN(40), 4)
+		right := testRandomLines(random, random.IntN(40), 4)
+		fullResult := diff.DiffLines(left, right)
+		linearResult := diff.DiffLines(left, right, diff.WithLinearSpaceThreshold(0))
+		require.Equal(t, testEditCount(fullResult), testEditCount(linearResult))
+	}
+}
+
+func TestDiffLinearMinimal(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+	for range 10 {
+		// inputs exceeding the linear space threshold
+		left := testRandomLines(random, 520+random.IntN(200), 4)
+		right := testRandomLines(random, 520+random.IntN(200), 4)
+		result := diff.DiffLines(left, right)
+		require.Equal(t, testMinimalEditCount(left, right), testEditCount(result))
+		leftLines := make([]string, 0, len(left))
+		rightLines := make([]string, 0, len(right))
+		for _, lineDiff := range result.Diffs {
+			if lineDiff.Op != diff.AddOp {
+				leftLines = append(leftLines, lineDiff.Line)
+			}
+			if lineDiff.Op != diff.DelOp {
+				rightLines = append(rightLines, lineDiff.Line)
+			}
+		}
+		require.Equal(t, left, leftLines)
+		require.Equal(t, right, rightLines)
+	}
+}
+
+func TestDiffPrefixSuffix(t *testing.T) {
+	left := []string{"a\n", "b\n", "a\n"}
+	right := []string{"a\n", "b\n", "b\n", "a\n"}
+	result := diff.DiffLines(left, right)
+	require.Equal(t, []diff.LineDiff{
+		{Op: diff.EqlOp, Line: "a\n", LeftNumber: 1, RightNumber: 1},
+		{Op: diff.EqlOp, Line: "b\n", LeftNumber: 2, RightNumber: 2},
+		{Op: diff.AddOp, Line: "b\n", RightNumber: 3},
+		{Op: diff.EqlOp, Line: "a\n", LeftNumber: 3, RightNumber: 4},
+	}, result.Diffs)
+}
+
+func TestDiffReconstruct(t *testing.T) {
+	algorithms := []diff.Algorithm{
+		diff.MyersAlgorithm,
+		diff.PatienceAlgorithm,
+		diff.HistogramAlgorithm,
+	}
+	optionSets := [][]diff.DiffOption{
+		{},
+		{diff.WithLinearSpaceThreshold(0)},
+		{diff.WithMaxEditCost(4)},
+		{diff.WithIgnoreCase(), diff.WithIgnoreAllSpace()},
+		{diff.WithIgnoreBlankLines()},
+	}
+	random := rand.New(rand.NewPCG(1, 2))
+	for _, algorithm := range algorithms {
+		for _, optionSet := range optionSets {
+			opts := append([]diff.DiffOption{diff.WithAlgorithm(algorithm)}, optionSet...)
+			for range 20 {
+				left := testRandomLines(random, random.IntN(60), 6)
+				right := testRandomLines(random, random.IntN(60), 6)
+				testRequireSides(t, left, right, diff.DiffLines(left, right, opts...))
+			}
+			result, err := diff.DiffFiles(leftFileName, rightFileName, opts...)
+			require.NoError(t, err)
+			testRequireSides(t, testReadLines(t, leftFileName), testReadLines(t, rightFileName), result)
+		}
+	}
+}
+
+func TestDiffReconstructReader(t *testing.T) {
+	left := []string{"a\n", "B \n", "c"}
+	right := []string{"a\n", "b\n", "d\n"}
+	result := diff.DiffLines(left, right, diff.WithIgnoreCase(), diff.WithIgnoreSpaceChange())
+	leftContent, err := io.ReadAll(result.LeftReader())
+	require.NoError(t, err)
+	require.Equal(t, "a\nB \nc", string(leftContent))
+	rightContent, err := io.ReadAll(result.RightReader())
+	require.NoError(t, err)
+	require.Equal(t, "a\nb\nd\n", string(rightContent))
+}
+
+func BenchmarkDiffSmallEdit(b *testing.B) {
+	random := rand.New(rand.NewPCG(1, 2))
+	left := testRandomLines(random, 200000, 1<<20)
+	right := slices.Clone(left)
+	right[len(right)/2] = "changed\n"
+	b.ReportAllocs()
+	for b.Loop() {
+		diff.DiffLines(left, right)
+	}
+}
+
+func BenchmarkDiffFull(b *testing.B) {
+	benchmarkDiff(b, diff.WithLinearSpaceThreshold(1<<30))
+}
+
+func BenchmarkDiffLinear(b *testing.B) {
+	benchmarkDiff(b, diff.WithLinearSpaceThreshold(0))
+}
+
+func benchmarkDiff(b *testing.B, opts ...diff.DiffOption) {
+	random := rand.New(rand.NewPCG(1, 2))
+	left := testRandomLines(random, 20000, 1<<20)
+	right := slices.Clone(left)
+	for range 200 {
+		right[random.IntN(len(right))] = "changed\n"
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		diff.DiffLines(left, right, opts...)
+	}
+}
+
+func testRandomLines(random *rand.Rand, n int, distinct int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%d\n", random.IntN(distinct))
+	}
+	return lines
+}
+
+func testEditCount(result *diff.Result) int {
+	count := 0
+	for _, lineDiff := range result.Diffs {
+		if lineDiff.Op != diff.EqlOp {
+			count++
+		}
+	}
+	return count
+}
+
+// testMinimalEditCount determines the minimal number of added and deleted
+// lines via the longest common subsequence of both sides.
+func testMinimalEditCount(left []string, right []string) int {
+	previous := make([]int, len(right)+1)
+	current := make([]int, len(right)+1)
+	for x := range left {
+		for y := range right {
+			if left[x] == right[y] {
+				current[y+1] = previous[y] + 1
+			} else {
+				current[y+1] = max(previous[y+1], current[y])
+			}
+		}
+		previous, current = current, previous
+	}
+	return len(left) + len(right) - 2*previous[len(right)]
+}
+
 func testDiff(t *testing.T, leftName string, rightName string) *diff.Result {
 	fileResult := testDiffFiles(t, leftName, rightName)
 	readersResult := testDiffReaders(t, leftName, rightName)
@@ -78,14 +259,16 @@ func testDiffReaders(t *testing.T, leftName string, rightName string) *diff.Resu
 }
 
 func testDiffLines(t *testing.T, leftName string, rightName string) *diff.Result {
-	leftData, err := os.ReadFile(leftName)
-	require.NoError(t, err)
-	left := slices.AppendSeq([]string{}, strings.Lines(string(leftData)))
-	rightData, err := os.ReadFile(rightName)
-	require.NoError(t, err)
-	right := slices.AppendSeq([]string{}, strings.Lines(string(rightData)))
+	left := testReadLines(t, leftName)
+	right := testReadLines(t, rightName)
 	result := diff.DiffLines(left, right)
 	require.Equal(t, diff.DefaultLeftName, result.LeftName)
 	require.Equal(t, diff.DefaultRightName, result.RightName)
 	return result
 }
+
+func testReadLines(t *testing.T, name string) []string {
+	data, err := os.ReadFile(name)
+	require.NoError(t, err)
+	return slices.AppendSeq([]string{}, strings.Lines(string(data)))
+}
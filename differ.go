@@ -0,0 +1,287 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"context"
+	"io"
+	"os"
+)
+
+// Algorithm defines the algorithm used to determine the differences
+// between left and right lines.
+type Algorithm int
+
+const (
+	// MyersAlgorithm selects the Myers diff algorithm (default).
+	MyersAlgorithm Algorithm = 0
+	// PatienceAlgorithm selects the patience diff algorithm, which anchors
+	// the diff on lines occurring exactly once on both sides.
+	PatienceAlgorithm Algorithm = 1
+	// HistogramAlgorithm selects the histogram diff algorithm (as used by git),
+	// which anchors the diff on the lines occurring least often. Like git, the
+	// resulting changes are compacted (see [CompactCleanup]).
+	HistogramAlgorithm Algorithm = 2
+)
+
+// DefaultLinearSpaceThreshold defines the default input size (1024 lines in total)
+// above which the linear space variant of the Myers algorithm is used.
+const DefaultLinearSpaceThreshold int = 1024
+
+// Differ type supports configurable diffing of line based content.
+type Differ struct {
+	leftName          string
+	rightName         string
+	algorithm         Algorithm
+	ignoreCase        bool
+	ignoreSpaceChange bool
+	ignoreAllSpace    bool
+	ignoreBlankLines  bool
+	stripTrailingCR   bool
+	lineKey           func(string) string
+	lineEqual         func(string, string) bool
+	linearThreshold   int
+	maxCost           int
+	graphemes         bool
+	cleanup           CleanupFlag
+	completeLastLine  bool
+}
+
+// DiffFiles runs a diff operation on the two given file names.
+//
+// The file names are used to name the diff result, unless explicit
+// names have been set via [WithLeftName] or [WithRightName].
+func (d *Differ) DiffFiles(leftName string, rightName string) (*Result, error) {
+	return d.DiffFilesContext(context.Background(), leftName, rightName)
+}
+
+// DiffFilesContext runs a diff operation on the two given file names
+// using the given context.
+//
+// If the context is canceled while the diff operation is running, the
+// context's error is returned.
+func (d *Differ) DiffFilesContext(ctx context.Context, leftName string, rightName string) (*Result, error) {
+	left, err := os.Open(leftName)
+	if err != nil {
+		return nil, err
+	}
+	defer left.Close()
+	right, err := os.Open(rightName)
+	if err != nil {
+		return nil, err
+	}
+	defer right.Close()
+	return d.diffReaders(ctx, left, d.name(d.leftName, leftName), right, d.name(d.rightName, rightName))
+}
+
+// DiffLines runs a diff operation on the two given string arrays.
+func (d *Differ) DiffLines(left []string, right []string) *Result {
+	// a background context is never canceled and hence there is no error
+	result, _ := d.DiffLinesContext(context.Background(), left, right)
+	return result
+}
+
+// DiffLinesContext runs a diff operation on the two given string arrays
+// using the given context.
+//
+// If the context is canceled while the diff operation is running, the
+// context's error is returned.
+func (d *Differ) DiffLinesContext(ctx context.Context, left []string, right []string) (*Result, error) {
+	return d.run(ctx, left, d.name(d.leftName, DefaultLeftName), right, d.name(d.rightName, DefaultRightName))
+}
+
+// Diff runs a diff operation on the two given reader's contents.
+func (d *Differ) Diff(left io.Reader, right io.Reader) (*Result, error) {
+	return d.DiffContext(context.Background(), left, right)
+}
+
+// DiffContext runs a diff operation on the two given reader's contents
+// using the given context.
+//
+// If the context is canceled while the diff operation is running, the
+// context's error is returned.
+func (d *Differ) DiffContext(ctx context.Context, left io.Reader, right io.Reader) (*Result, error) {
+	return d.diffReaders(ctx, left, d.name(d.leftName, DefaultLeftName), right, d.name(d.rightName, DefaultRightName))
+}
+
+func (d *Differ) name(name string, defaultName string) string {
+	if name == "" {
+		return defaultName
+	}
+	return name
+}
+
+func (d *Differ) diffReaders(ctx context.Context, left io.Reader, leftName string, right io.Reader, rightName string) (*Result, error) {
+	leftLines, err := readLines(left)
+	if err != nil {
+		return nil, err
+	}
+	rightLines, err := readLines(right)
+	if err != nil {
+		return nil, err
+	}
+	return d.run(ctx, leftLines, leftName, rightLines, rightName)
+}
+
+func (d *Differ) run(ctx context.Context, leftLines []string, leftName string, rightLines []string, rightName string) (*Result, error) {
+	keyLeftLines := leftLines
+	keyRightLines := rightLines
+	if d.completeLastLine {
+		keyLeftLines = completeLastLine(leftLines)
+		keyRightLines = completeLastLine(rightLines)
+	}
+	leftKeys := d.keys(keyLeftLines)
+	rightKeys := d.keys(keyRightLines)
+	var script *EditScript[string]
+	var err error
+	if d.lineEqual == nil {
+		script, err = runEditScript(ctx, d, leftKeys, rightKeys, equalComparable, internComparable)
+	} else {
+		intern := func(left []string, right []string) ([]int, []int) {
+			return internFunc(left, right, d.lineEqual)
+		}
+		script, err = runEditScript(ctx, d, leftKeys, rightKeys, d.lineEqual, intern)
+	}
+	if err != nil {
+		return nil, err
+	}
+	equal := d.lineEqual
+	if equal == nil {
+		equal = equalComparable
+	}
+	edits := cleanupEdits(d, script.Edits, leftKeys, rightKeys, equal, leftLines, rightLines)
+	result := &Result{
+		LeftName:       leftName,
+		RightName:      rightName,
+		Diffs:          make([]LineDiff, 0, len(edits)),
+		LeftNoNewline:  hasNoNewline(leftLines),
+		RightNoNewline: hasNoNewline(rightLines),
+		Heuristic:      script.Heuristic,
+	}
+	for _, edit := range edits {
+		switch edit.Op {
+		case EqlOp:
+			result.keepLine(leftLines[edit.LeftIndex], rightLines[edit.RightIndex], edit.LeftIndex+1, edit.RightIndex+1)
+		case AddOp:
+			result.addLine(rightLines[edit.RightIndex], edit.RightIndex+1)
+		case DelOp:
+			result.deleteLine(leftLines[edit.LeftIndex], edit.LeftIndex+1)
+		}
+	}
+	if d.ignoreBlankLines {
+		d.ignoreBlankChanges(result.Diffs)
+	}
+	return result, nil
+}
+
+func (d *Differ) runAlgorithm(s *script, a []int, b []int) {
+	switch d.algorithm {
+	case PatienceAlgorithm:
+		runPatience(s, a, b)
+	case HistogramAlgorithm:
+		runHistogram(s, a, b)
+		// git's histogram output is always compacted
+		compactScript(s, a, b)
+	default:
+		runMyers(s, a, b)
+	}
+}
+
+// DiffOption interface is used to configure a Differ instance.
+type DiffOption interface {
+	// Apply applies the options represented by this instance
+	// to the given Differ instance.
+	Apply(d *Differ)
+}
+
+// DiffOptionFunc typed functions are used to configure a Differ instance.
+type DiffOptionFunc func(*Differ)
+
+// Apply applies options to the given Differ instance.
+func (f DiffOptionFunc) Apply(d *Differ) {
+	f(d)
+}
+
+// WithLeftName sets the name of the left side of the diff result.
+//
+// Per default the file name (for files) or [DefaultLeftName] is used.
+func WithLeftName(name string) DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.leftName = name
+	})
+}
+
+// WithRightName sets the name of the right side of the diff result.
+//
+// Per default the file name (for files) or [DefaultRightName] is used.
+func WithRightName(name string) DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.rightName = name
+	})
+}
+
+// WithAlgorithm sets the algorithm to use for diffing.
+//
+// Per default the Myers algorithm is used.
+func WithAlgorithm(algorithm Algorithm) DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.algorithm = algorithm
+	})
+}
+
+// WithLineKey sets a function normalizing lines before comparison.
+//
+// Lines with equal keys are considered equal. The diff result still
+// contains the original lines. The function is invoked after any other
+// normalization (e.g. [WithIgnoreCase]) has been applied.
+func WithLineKey(key func(line string) string) DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.lineKey = key
+	})
+}
+
+// WithLinearSpaceThreshold sets the input size (sum of left and right lines)
+// above which the linear space variant of the Myers algorithm is used.
+//
+// The full variant records the complete trace of the algorithm and therefore
+// requires O((N+M)*D) memory, whereas the linear space variant only requires
+// O(N+M) memory at the cost of repeatedly scanning the input. A negative
+// threshold resets the setting to [DefaultLinearSpaceThreshold].
+func WithLinearSpaceThreshold(threshold int) DiffOption {
+	checkedThreshold := threshold
+	if checkedThreshold < 0 {
+		checkedThreshold = DefaultLinearSpaceThreshold
+	}
+	return DiffOptionFunc(func(d *Differ) {
+		d.linearThreshold = checkedThreshold
+	})
+}
+
+// WithMaxEditCost limits the edit cost (number of edit steps) the Myers
+// algorithm explores to determine the minimal diff.
+//
+// If the limit is exceeded, the algorithm continues with a heuristic and the
+// diff result is no longer minimal (see [Result.Heuristic]). This bounds the
+// runtime of diff operations on pathological inputs. A cost less or equal to 0
+// disables the limit (default).
+func WithMaxEditCost(cost int) DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.maxCost = max(cost, 0)
+	})
+}
+
+// NewDiffer creates a new Differ instance using the given diff options.
+func NewDiffer(opts ...DiffOption) *Differ {
+	differ := &Differ{
+		algorithm:       MyersAlgorithm,
+		linearThreshold: DefaultLinearSpaceThreshold,
+	}
+	for _, opt := range opts {
+		opt.Apply(differ)
+	}
+	return differ
+}
@@ -0,0 +1,108 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestDifferNames(t *testing.T) {
+	differ := diff.NewDiffer(diff.WithLeftName("a/file.txt"), diff.WithRightName("b/file.txt"))
+	fileResult, err := differ.DiffFiles(leftFileName, rightFileName)
+	require.NoError(t, err)
+	require.Equal(t, "a/file.txt", fileResult.LeftName)
+	require.Equal(t, "b/file.txt", fileResult.RightName)
+	left, err := os.Open(leftFileName)
+	require.NoError(t, err)
+	defer left.Close()
+	right, err := os.Open(rightFileName)
+	require.NoError(t, err)
+	defer right.Close()
+	readersResult, err := differ.Diff(left, right)
+	require.NoError(t, err)
+	require.Equal(t, "a/file.txt", readersResult.LeftName)
+	require.Equal(t, "b/file.txt", readersResult.RightName)
+	require.Equal(t, fileResult.Diffs, readersResult.Diffs)
+	linesResult := differ.DiffLines([]string{}, []string{})
+	require.Equal(t, "a/file.txt", linesResult.LeftName)
+	require.Equal(t, "b/file.txt", linesResult.RightName)
+}
+
+func TestDifferLineKey(t *testing.T) {
+	left := []string{
+		"Unchanged line\n",
+		"removed line\n",
+	}
+	right := []string{
+		"UNCHANGED LINE\n",
+		"added line\n",
+	}
+	result := diff.DiffLines(left, right, diff.WithLineKey(strings.ToLower))
+	require.Equal(t, []diff.LineDiff{
+		{Op: diff.EqlOp, Line: "Unchanged line\n", RightLine: "UNCHANGED LINE\n", LeftNumber: 1, RightNumber: 1},
+		{Op: diff.DelOp, Line: "removed line\n", LeftNumber: 2},
+		{Op: diff.AddOp, Line: "added line\n", RightNumber: 2},
+	}, result.Diffs)
+}
+
+func TestDifferAlgorithms(t *testing.T) {
+	algorithms := []diff.Algorithm{
+		diff.MyersAlgorithm,
+		diff.PatienceAlgorithm,
+		diff.HistogramAlgorithm,
+	}
+	for _, algorithm := range algorithms {
+		differ := diff.NewDiffer(diff.WithAlgorithm(algorithm), diff.WithLinearSpaceThreshold(0))
+		result, err := differ.DiffFiles(leftFileName, rightFileName)
+		require.NoError(t, err)
+		require.Len(t, result.Diffs, 39)
+		require.Equal(t, 13, testEditCount(result))
+	}
+}
+
+func TestDifferContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	for _, algorithm := range []diff.Algorithm{diff.MyersAlgorithm, diff.PatienceAlgorithm, diff.HistogramAlgorithm} {
+		_, err := diff.DiffFilesContext(ctx, leftFileName, rightFileName, diff.WithAlgorithm(algorithm))
+		require.ErrorIs(t, err, context.Canceled)
+		_, err = diff.DiffFilesContext(ctx, leftFileName, rightFileName, diff.WithAlgorithm(algorithm), diff.WithLinearSpaceThreshold(0))
+		require.ErrorIs(t, err, context.Canceled)
+	}
+	result, err := diff.DiffFilesContext(t.Context(), leftFileName, rightFileName)
+	require.NoError(t, err)
+	require.Len(t, result.Diffs, 39)
+	require.False(t, result.Heuristic)
+}
+
+func TestDifferMaxEditCost(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+	for _, threshold := range []int{0, diff.DefaultLinearSpaceThreshold} {
+		for range 50 {
+			left := testRandomLines(random, 50+random.IntN(50), 8)
+			right := testRandomLines(random, 50+random.IntN(50), 8)
+			minimal := diff.DiffLines(left, right, diff.WithLinearSpaceThreshold(threshold))
+			require.False(t, minimal.Heuristic)
+			result := diff.DiffLines(left, right, diff.WithLinearSpaceThreshold(threshold), diff.WithMaxEditCost(4))
+			require.True(t, result.Heuristic)
+			require.GreaterOrEqual(t, testEditCount(result), testEditCount(minimal))
+			testRequireSides(t, left, right, result)
+		}
+	}
+}
+
+func testRequireSides(t *testing.T, left []string, right []string, result *diff.Result) {
+	require.Equal(t, left, result.Left())
+	require.Equal(t, right, result.Right())
+}
@@ -0,0 +1,105 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"fmt"
+	"slices"
+)
+
+// WithEdFormatter wraps WithFormatter to format the ouput as ed script
+// (like diff -e).
+//
+// The script's commands are ordered from the end to the beginning of the
+// left lines, so that line numbers remain valid while running the script.
+// As an ed script can not express a missing newline at the end of the last
+// line, the diff should be run using [WithCompleteLastLine] to get the same
+// script as GNU diff.
+func WithEdFormatter() PrinterOption {
+	return PrinterOptionFunc(func(p *Printer) {
+		p.formatter = &edFormatter{}
+	})
+}
+
+// WithRCSFormatter wraps WithFormatter to format the ouput in RCS diff format
+// (like diff -n).
+func WithRCSFormatter() PrinterOption {
+	return PrinterOptionFunc(func(p *Printer) {
+		p.formatter = &rcsFormatter{}
+	})
+}
+
+// WithCompleteLastLine compares a last line missing its terminating newline
+// like the same line terminated by a newline (like GNU diff does for ed
+// scripts, see [WithEdFormatter]).
+//
+// The lines are still reported as is and the missing newline is still
+// recorded in the diff result (see [Result.LeftNoNewline] and
+// [Result.RightNoNewline]).
+func WithCompleteLastLine() DiffOption {
+	return DiffOptionFunc(func(d *Differ) {
+		d.completeLastLine = true
+	})
+}
+
+type edFormatter struct{}
+
+func (f *edFormatter) Format(p *Printer, r *Result) {
+	colors := p.Colors()
+	hunks := slices.Collect(r.Hunks(0))
+	slices.Reverse(hunks)
+	for _, hunk := range hunks {
+		fmt.Fprintf(p, "%s%s%c%s\n", colors.Lbl, formatLineRange(hunk.LeftStart, hunk.LeftLength), scriptCommand(hunk), colors.Rst)
+		if hunk.RightLength > 0 {
+			f.formatLines(p, hunk)
+		}
+	}
+}
+
+func (f *edFormatter) formatLines(p *Printer, hunk Hunk) {
+	colors := p.Colors()
+	adds := make([]string, 0, hunk.RightLength)
+	for _, diff := range hunk.Diffs {
+		if diff.Op == AddOp {
+			adds = append(adds, diff.Line)
+		}
+	}
+	for index, line := range adds {
+		// a line consisting of a single dot would terminate the input mode and hence
+		// is written with a double dot, which is corrected by a subsequent substitution
+		if line == ".\n" || line == "." {
+			fmt.Fprintf(p, "%s..\n%s", colors.Add, colors.Rst)
+			fmt.Fprintf(p, "%s.\ns/.//\n%s", colors.Lbl, colors.Rst)
+			if index == len(adds)-1 {
+				return
+			}
+			fmt.Fprintf(p, "%sa\n%s", colors.Lbl, colors.Rst)
+			continue
+		}
+		fmt.Fprintf(p, "%s%s%s%s", colors.Add, line, lineEnd(line), colors.Rst)
+	}
+	fmt.Fprintf(p, "%s.\n%s", colors.Lbl, colors.Rst)
+}
+
+type rcsFormatter struct{}
+
+func (f *rcsFormatter) Format(p *Printer, r *Result) {
+	colors := p.Colors()
+	for hunk := range r.Hunks(0) {
+		if hunk.LeftLength > 0 {
+			fmt.Fprintf(p, "%sd%d %d%s\n", colors.Lbl, hunk.LeftStart, hunk.LeftLength, colors.Rst)
+		}
+		if hunk.RightLength > 0 {
+			fmt.Fprintf(p, "%sa%d %d%s\n", colors.Lbl, hunk.LeftStart+max(hunk.LeftLength-1, 0), hunk.RightLength, colors.Rst)
+			for _, diff := range hunk.Diffs {
+				if diff.Op == AddOp {
+					fmt.Fprintf(p, "%s%s%s", colors.Add, diff.Line, colors.Rst)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestEd(t *testing.T) {
+	testScriptFormatter(t, "testdata/script/", "ed.diff", diff.WithEdFormatter())
+	testScriptFormatter(t, "testdata/script/dot_", "ed.diff", diff.WithEdFormatter())
+}
+
+func TestEdCompleteLastLine(t *testing.T) {
+	result, err := diff.DiffFiles("testdata/script/noeol_l.txt", "testdata/script/noeol_r.txt", diff.WithCompleteLastLine())
+	require.NoError(t, err)
+	require.True(t, result.LeftNoNewline)
+	expected, err := os.ReadFile("testdata/script/noeol_ed.diff")
+	require.NoError(t, err)
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithEdFormatter()).Print(result)
+	require.Equal(t, string(expected), output.String())
+	// the line key function sees the completed last line
+	result = diff.DiffLines([]string{"a"}, []string{"b\n", "a\n"}, diff.WithCompleteLastLine(), diff.WithLineKey(strings.TrimSpace))
+	require.Equal(t, []diff.LineDiff{
+		{Op: diff.AddOp, Line: "b\n", RightNumber: 1},
+		{Op: diff.EqlOp, Line: "a", RightLine: "a\n", LeftNumber: 1, RightNumber: 2},
+	}, result.Diffs)
+}
+
+func TestRCS(t *testing.T) {
+	testScriptFormatter(t, "testdata/script/", "rcs.diff", diff.WithRCSFormatter())
+	testScriptFormatter(t, "testdata/script/dot_", "rcs.diff", diff.WithRCSFormatter())
+}
@@ -0,0 +1,201 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"context"
+)
+
+// Edit represents a single operation of an edit script.
+type Edit[T any] struct {
+	// Op indicates the edit operation.
+	Op Op
+	// LeftIndex contains the index of the left element
+	// (-1 in case of AddOp).
+	LeftIndex int
+	// RightIndex contains the index of the right element
+	// (-1 in case of DelOp).
+	RightIndex int
+	// Left contains the left element
+	// (zero value in case of AddOp).
+	Left T
+	// Right contains the right element
+	// (zero value in case of DelOp).
+	Right T
+}
+
+// EditScript contains the result of a generic diff operation.
+type EditScript[T any] struct {
+	// Edits contains for all compared elements the edit operation.
+	Edits []Edit[T]
+	// Heuristic indicates, the edit script is not minimal, because
+	// the edit cost limit has been exceeded (see [WithMaxEditCost]).
+	Heuristic bool
+}
+
+// DiffSlices runs a diff operation on the two given slices
+// using the given diff options.
+//
+// Only the options controlling the diff algorithm are considered
+// (e.g. [WithAlgorithm]). Line related options are ignored.
+func DiffSlices[T comparable](left []T, right []T, opts ...DiffOption) *EditScript[T] {
+	// a background context is never canceled and hence there is no error
+	script, _ := DiffSlicesContext(context.Background(), left, right, opts...)
+	return script
+}
+
+// DiffSlicesContext runs a diff operation on the two given slices
+// using the given diff options and context.
+//
+// If the context is canceled while the diff operation is running, the
+// context's error is returned.
+func DiffSlicesContext[T comparable](ctx context.Context, left []T, right []T, opts ...DiffOption) (*EditScript[T], error) {
+	differ := NewDiffer(opts...)
+	script, err := runEditScript(ctx, differ, left, right, equalComparable, internComparable)
+	if err != nil {
+		return nil, err
+	}
+	script.Edits = cleanupEdits(differ, script.Edits, left, right, equalComparable, nil, nil)
+	return script, nil
+}
+
+// DiffFunc runs a diff operation on the two given slices using the given
+// equal function and diff options.
+//
+// As the elements can not be hashed, each element is compared with all
+// distinct elements seen so far, making this function considerably slower
+// than [DiffSlices] for large inputs.
+func DiffFunc[T any](left []T, right []T, equal func(T, T) bool, opts ...DiffOption) *EditScript[T] {
+	// a background context is never canceled and hence there is no error
+	script, _ := DiffFuncContext(context.Background(), left, right, equal, opts...)
+	return script
+}
+
+// DiffFuncContext runs a diff operation on the two given slices using the
+// given equal function, diff options and context.
+//
+// If the context is canceled while the diff operation is running, the
+// context's error is returned.
+func DiffFuncContext[T any](ctx context.Context, left []T, right []T, equal func(T, T) bool, opts ...DiffOption) (*EditScript[T], error) {
+	intern := func(left []T, right []T) ([]int, []int) {
+		return internFunc(left, right, equal)
+	}
+	differ := NewDiffer(opts...)
+	script, err := runEditScript(ctx, differ, left, right, equal, intern)
+	if err != nil {
+		return nil, err
+	}
+	script.Edits = cleanupEdits(differ, script.Edits, left, right, equal, nil, nil)
+	return script, nil
+}
+
+func runEditScript[T any](ctx context.Context, d *Differ, left []T, right []T, equal func(T, T) bool, intern func([]T, []T) ([]int, []int)) (*EditScript[T], error) {
+	prefix, suffix := 0, 0
+	// like git, the histogram algorithm counts the occurrences on the untrimmed
+	// input, as the common lines influence the selection of the anchors
+	if d.algorithm != HistogramAlgorithm {
+		prefix, suffix = commonPrefixSuffix(left, right, equal)
+	}
+	leftIDs, rightIDs := intern(left[prefix:len(left)-suffix], right[prefix:len(right)-suffix])
+	s := newScript(ctx, d.linearThreshold, d.maxCost)
+	d.runAlgorithm(s, leftIDs, rightIDs)
+	if s.err != nil {
+		return nil, s.err
+	}
+	script := &EditScript[T]{
+		Edits:     make([]Edit[T], 0, len(left)+len(right)),
+		Heuristic: s.heuristic,
+	}
+	x := 0
+	y := 0
+	keep := func() {
+		script.Edits = append(script.Edits, Edit[T]{Op: EqlOp, LeftIndex: x, RightIndex: y, Left: left[x], Right: right[y]})
+		x++
+		y++
+	}
+	for range prefix {
+		keep()
+	}
+	for _, op := range s.ops {
+		switch op {
+		case EqlOp:
+			keep()
+		case AddOp:
+			script.Edits = append(script.Edits, Edit[T]{Op: AddOp, LeftIndex: -1, RightIndex: y, Right: right[y]})
+			y++
+		case DelOp:
+			script.Edits = append(script.Edits, Edit[T]{Op: DelOp, LeftIndex: x, RightIndex: -1, Left: left[x]})
+			x++
+		}
+	}
+	for range suffix {
+		keep()
+	}
+	return script, nil
+}
+
+func equalComparable[T comparable](a T, b T) bool {
+	return a == b
+}
+
+// commonPrefixSuffix determines the number of equal leading and trailing
+// elements of both sides. Prefix and suffix never overlap.
+func commonPrefixSuffix[T any](left []T, right []T, equal func(T, T) bool) (int, int) {
+	limit := min(len(left), len(right))
+	prefix := 0
+	for prefix < limit && equal(left[prefix], right[prefix]) {
+		prefix++
+	}
+	limit -= prefix
+	suffix := 0
+	for suffix < limit && equal(left[len(left)-suffix-1], right[len(right)-suffix-1]) {
+		suffix++
+	}
+	return prefix, suffix
+}
+
+// internComparable maps each distinct element to an integer id, so that the
+// diff algorithms only have to compare integers.
+func internComparable[T comparable](left []T, right []T) ([]int, []int) {
+	ids := make(map[T]int, len(left)+len(right))
+	intern := func(elements []T) []int {
+		elementIDs := make([]int, len(elements))
+		for i, element := range elements {
+			id, ok := ids[element]
+			if !ok {
+				id = len(ids)
+				ids[element] = id
+			}
+			elementIDs[i] = id
+		}
+		return elementIDs
+	}
+	return intern(left), intern(right)
+}
+
+// internFunc maps each distinct element to an integer id, so that the
+// diff algorithms only have to compare integers. Each element is compared
+// with the already known distinct elements using the given equal function.
+func internFunc[T any](left []T, right []T, equal func(T, T) bool) ([]int, []int) {
+	distinct := make([]T, 0)
+	intern := func(elements []T) []int {
+		elementIDs := make([]int, len(elements))
+	next:
+		for i, element := range elements {
+			for id, distinctElement := range distinct {
+				if equal(distinctElement, element) {
+					elementIDs[i] = id
+					continue next
+				}
+			}
+			elementIDs[i] = len(distinct)
+			distinct = append(distinct, element)
+		}
+		return elementIDs
+	}
+	return intern(left), intern(right)
+}
@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestDiffSlices(t *testing.T) {
+	left := []int{1, 2, 3, 4}
+	right := []int{2, 3, 5, 4}
+	script := diff.DiffSlices(left, right)
+	require.False(t, script.Heuristic)
+	require.Equal(t, []diff.Edit[int]{
+		{Op: diff.DelOp, LeftIndex: 0, RightIndex: -1, Left: 1},
+		{Op: diff.EqlOp, LeftIndex: 1, RightIndex: 0, Left: 2, Right: 2},
+		{Op: diff.EqlOp, LeftIndex: 2, RightIndex: 1, Left: 3, Right: 3},
+		{Op: diff.AddOp, LeftIndex: -1, RightIndex: 2, Right: 5},
+		{Op: diff.EqlOp, LeftIndex: 3, RightIndex: 3, Left: 4, Right: 4},
+	}, script.Edits)
+}
+
+func TestDiffFunc(t *testing.T) {
+	type token struct {
+		Text   string
+		Offset int
+	}
+	left := []token{{"a", 0}, {"b", 2}, {"c", 4}}
+	right := []token{{"A", 0}, {"C", 2}}
+	equal := func(a token, b token) bool {
+		return strings.EqualFold(a.Text, b.Text)
+	}
+	for _, algorithm := range []diff.Algorithm{diff.MyersAlgorithm, diff.PatienceAlgorithm, diff.HistogramAlgorithm} {
+		script := diff.DiffFunc(left, right, equal, diff.WithAlgorithm(algorithm))
+		require.Equal(t, []diff.Edit[token]{
+			{Op: diff.EqlOp, LeftIndex: 0, RightIndex: 0, Left: left[0], Right: right[0]},
+			{Op: diff.DelOp, LeftIndex: 1, RightIndex: -1, Left: left[1]},
+			{Op: diff.EqlOp, LeftIndex: 2, RightIndex: 1, Left: left[2], Right: right[1]},
+		}, script.Edits)
+	}
+}
+
+func TestDiffSlicesMatchesLines(t *testing.T) {
+	result := testDiff(t, leftFileName, rightFileName)
+	left := testReadLines(t, leftFileName)
+	right := testReadLines(t, rightFileName)
+	script := diff.DiffSlices(left, right)
+	require.Len(t, script.Edits, len(result.Diffs))
+	for i, edit := range script.Edits {
+		require.Equal(t, result.Diffs[i].Op, edit.Op)
+	}
+}
+
+func TestDiffSlicesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err := diff.DiffSlicesContext(ctx, []int{1, 2, 3}, []int{3, 2, 1})
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = diff.DiffFuncContext(ctx, []int{1, 2, 3}, []int{3, 2, 1}, func(a int, b int) bool { return a == b })
+	require.ErrorIs(t, err, context.Canceled)
+}
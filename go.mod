@@ -4,7 +4,7 @@ go 1.26.3
 
 require github.com/stretchr/testify v1.11.1
 
-require golang.org/x/sys v0.47.0 // indirect
+require golang.org/x/sys v0.47.0
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
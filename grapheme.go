@@ -0,0 +1,174 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// graphemeClass defines the properties of a rune relevant
+// for grapheme cluster segmentation.
+type graphemeClass int
+
+const (
+	otherGrapheme graphemeClass = iota
+	crGrapheme
+	lfGrapheme
+	controlGrapheme
+	extendGrapheme
+	zwjGrapheme
+	spacingMarkGrapheme
+	prependGrapheme
+	regionalIndicatorGrapheme
+	hangulLGrapheme
+	hangulVGrapheme
+	hangulTGrapheme
+	hangulLVGrapheme
+	hangulLVTGrapheme
+	pictographicGrapheme
+)
+
+// pictographicRunes contains the rune ranges considered as pictographic
+// (an approximation of the Extended_Pictographic property).
+var pictographicRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00a9, Hi: 0x00ae, Stride: 5},
+		{Lo: 0x203c, Hi: 0x2049, Stride: 13},
+		{Lo: 0x2122, Hi: 0x2139, Stride: 23},
+		{Lo: 0x2194, Hi: 0x21aa, Stride: 1},
+		{Lo: 0x231a, Hi: 0x23ff, Stride: 1},
+		{Lo: 0x24c2, Hi: 0x24c2, Stride: 1},
+		{Lo: 0x25aa, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2b05, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x303d, Stride: 13},
+		{Lo: 0x3297, Hi: 0x3299, Stride: 2},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f000, Hi: 0x1f0ff, Stride: 1},
+		{Lo: 0x1f10d, Hi: 0x1f1e5, Stride: 1},
+		{Lo: 0x1f201, Hi: 0x1f3fa, Stride: 1},
+		{Lo: 0x1f400, Hi: 0x1faff, Stride: 1},
+		{Lo: 0x1fc00, Hi: 0x1fffd, Stride: 1},
+	},
+}
+
+// classifyGrapheme determines the grapheme cluster properties of a rune.
+func classifyGrapheme(r rune) graphemeClass {
+	switch {
+	case r == '\r':
+		return crGrapheme
+	case r == '\n':
+		return lfGrapheme
+	case r == 0x200d:
+		return zwjGrapheme
+	case r == 0x200c || (r >= 0xfe00 && r <= 0xfe0f) || (r >= 0x1f3fb && r <= 0x1f3ff) || (r >= 0xe0020 && r <= 0xe007f):
+		return extendGrapheme
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return extendGrapheme
+	case unicode.Is(unicode.Mc, r):
+		return spacingMarkGrapheme
+	case r == 0x0600 || r == 0x0601 || r == 0x0602 || r == 0x0603 || r == 0x0604 || r == 0x0605 || r == 0x06dd || r == 0x070f || r == 0x110bd:
+		return prependGrapheme
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return controlGrapheme
+	case r >= 0x1f1e6 && r <= 0x1f1ff:
+		return regionalIndicatorGrapheme
+	case (r >= 0x1100 && r <= 0x115f) || (r >= 0xa960 && r <= 0xa97c):
+		return hangulLGrapheme
+	case (r >= 0x1160 && r <= 0x11a7) || (r >= 0xd7b0 && r <= 0xd7c6):
+		return hangulVGrapheme
+	case (r >= 0x11a8 && r <= 0x11ff) || (r >= 0xd7cb && r <= 0xd7fb):
+		return hangulTGrapheme
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return hangulLVGrapheme
+		}
+		return hangulLVTGrapheme
+	case unicode.Is(pictographicRunes, r):
+		return pictographicGrapheme
+	}
+	return otherGrapheme
+}
+
+// splitGraphemes splits the given text into grapheme clusters.
+//
+// The segmentation follows the extended grapheme cluster rules of Unicode
+// Standard Annex #29 in a simplified form: The character properties are
+// approximated by general categories and fixed rune ranges.
+func splitGraphemes(text string) []string {
+	clusters := make([]string, 0, len(text))
+	start := 0
+	previous := otherGrapheme
+	// state for emoji ZWJ sequences and regional indicator pairs
+	pictographic := false
+	regionalIndicators := 0
+	for index, r := range text {
+		class := classifyGrapheme(r)
+		if index > 0 && graphemeBreak(previous, class, pictographic, regionalIndicators) {
+			clusters = append(clusters, text[start:index])
+			start = index
+			pictographic = false
+			regionalIndicators = 0
+		}
+		switch class {
+		case pictographicGrapheme:
+			pictographic = true
+		case regionalIndicatorGrapheme:
+			regionalIndicators++
+		case extendGrapheme, zwjGrapheme:
+		default:
+			pictographic = false
+		}
+		previous = class
+	}
+	if start < len(text) {
+		clusters = append(clusters, text[start:])
+	}
+	return clusters
+}
+
+// graphemeBreak checks whether there is a grapheme cluster boundary between
+// two runes of the given classes.
+func graphemeBreak(previous graphemeClass, class graphemeClass, pictographic bool, regionalIndicators int) bool {
+	switch {
+	case previous == crGrapheme && class == lfGrapheme:
+		return false
+	case previous == crGrapheme || previous == lfGrapheme || previous == controlGrapheme:
+		return true
+	case class == crGrapheme || class == lfGrapheme || class == controlGrapheme:
+		return true
+	case previous == hangulLGrapheme && (class == hangulLGrapheme || class == hangulVGrapheme || class == hangulLVGrapheme || class == hangulLVTGrapheme):
+		return false
+	case (previous == hangulLVGrapheme || previous == hangulVGrapheme) && (class == hangulVGrapheme || class == hangulTGrapheme):
+		return false
+	case (previous == hangulLVTGrapheme || previous == hangulTGrapheme) && class == hangulTGrapheme:
+		return false
+	case class == extendGrapheme || class == zwjGrapheme || class == spacingMarkGrapheme:
+		return false
+	case previous == prependGrapheme:
+		return false
+	case previous == zwjGrapheme && class == pictographicGrapheme && pictographic:
+		return false
+	case previous == regionalIndicatorGrapheme && class == regionalIndicatorGrapheme:
+		return regionalIndicators%2 == 0
+	}
+	return true
+}
+
+// splitRunes splits the given text into runes.
+func splitRunes(text string) []string {
+	runes := make([]string, 0, utf8.RuneCountInString(text))
+	for len(text) > 0 {
+		_, size := utf8.DecodeRuneInString(text)
+		runes = append(runes, text[:size])
+		text = text[size:]
+	}
+	return runes
+}
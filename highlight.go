@@ -0,0 +1,137 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// lineSegment represents a part of a changed line.
+type lineSegment struct {
+	text       string
+	emphasized bool
+}
+
+// highlightHunk determines the emphasized segments of the changed lines in
+// the given hunk. Within each block of changed lines, the deleted lines are
+// paired in order with the added lines and each pair is refined by diffing
+// the tokens of both lines. Lines not being part of a pair are not refined
+// (nil segments).
+func highlightHunk(hunk Hunk) [][]lineSegment {
+	segments := make([][]lineSegment, len(hunk.Diffs))
+	start := 0
+	for start < len(hunk.Diffs) {
+		if hunk.Diffs[start].Op == EqlOp {
+			start++
+			continue
+		}
+		dels := make([]int, 0)
+		adds := make([]int, 0)
+		end := start
+		for end < len(hunk.Diffs) && hunk.Diffs[end].Op != EqlOp {
+			if hunk.Diffs[end].Op == DelOp {
+				dels = append(dels, end)
+			} else {
+				adds = append(adds, end)
+			}
+			end++
+		}
+		for index := range min(len(dels), len(adds)) {
+			segments[dels[index]], segments[adds[index]] = highlightLines(hunk.Diffs[dels[index]].Line, hunk.Diffs[adds[index]].Line)
+		}
+		start = end
+	}
+	return segments
+}
+
+// highlightLines refines a pair of changed lines by diffing the tokens of both
+// lines. If both lines have no word token in common, they are not refined (nil
+// segments).
+func highlightLines(left string, right string) ([]lineSegment, []lineSegment) {
+	leftTokens := tokenizeLine(left)
+	rightTokens := tokenizeLine(right)
+	script := DiffSlices(leftTokens, rightTokens)
+	common := false
+	for _, edit := range script.Edits {
+		if edit.Op == EqlOp && strings.TrimSpace(edit.Left) != "" {
+			common = true
+			break
+		}
+	}
+	if !common {
+		return nil, nil
+	}
+	leftSegments := make([]lineSegment, 0)
+	rightSegments := make([]lineSegment, 0)
+	for _, edit := range script.Edits {
+		switch edit.Op {
+		case EqlOp:
+			leftSegments = appendSegment(leftSegments, edit.Left, false)
+			rightSegments = appendSegment(rightSegments, edit.Right, false)
+		case AddOp:
+			rightSegments = appendSegment(rightSegments, edit.Right, true)
+		case DelOp:
+			leftSegments = appendSegment(leftSegments, edit.Left, true)
+		}
+	}
+	return leftSegments, rightSegments
+}
+
+// appendSegment appends the given text to the segments, merging it
+// with the last segment if both have the same emphasis.
+func appendSegment(segments []lineSegment, text string, emphasized bool) []lineSegment {
+	// the line end is never emphasized
+	if text == "\n" {
+		emphasized = false
+	}
+	last := len(segments) - 1
+	if last >= 0 && segments[last].emphasized == emphasized {
+		segments[last].text += text
+		return segments
+	}
+	return append(segments, lineSegment{text: text, emphasized: emphasized})
+}
+
+// tokenizeLine splits a line into words (consisting of letters, digits and
+// underscores), runs of white space and single other characters. The
+// trailing newline is a token of its own.
+func tokenizeLine(line string) []string {
+	tokens := make([]string, 0)
+	start := 0
+	var startClass int
+	for index, r := range line {
+		class := tokenClass(r)
+		if index > start && (class != startClass || class == otherToken) {
+			tokens = append(tokens, line[start:index])
+			start = index
+		}
+		if index == start {
+			startClass = class
+		}
+	}
+	if start < len(line) {
+		tokens = append(tokens, line[start:])
+	}
+	return tokens
+}
+
+const (
+	wordToken = iota
+	spaceToken
+	otherToken
+)
+
+func tokenClass(r rune) int {
+	switch {
+	case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+		return wordToken
+	case r != '\n' && unicode.IsSpace(r):
+		return spaceToken
+	}
+	return otherToken
+}
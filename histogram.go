@@ -0,0 +1,146 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+// histogramMaxChain defines the maximum number of occurrences of a line
+// for being considered as an anchor (same as git's default).
+const histogramMaxChain = 64
+
+// runHistogram runs the histogram diff algorithm on the given interned lines.
+//
+// The implementation follows git's histogram diff: The longest common region
+// anchored on the lines occurring least often on the left side is used to split
+// the input in independent ranges, which are diffed recursively. If all common
+// lines occur too often, the Myers algorithm is used instead.
+func runHistogram(s *script, a []int, b []int) {
+	for {
+		if s.interrupted() {
+			return
+		}
+		switch {
+		case len(a) == 0:
+			s.add(len(b))
+			return
+		case len(b) == 0:
+			s.delete(len(a))
+			return
+		}
+		index := newHistogramIndex(a)
+		region, found, fallback := index.findLCS(b)
+		switch {
+		case fallback:
+			runMyers(s, a, b)
+			return
+		case !found:
+			s.delete(len(a))
+			s.add(len(b))
+			return
+		}
+		runHistogram(s, a[:region.beginA], b[:region.beginB])
+		s.keep(region.endA - region.beginA + 1)
+		a = a[region.endA+1:]
+		b = b[region.endB+1:]
+	}
+}
+
+// histogramRegion defines a common region (with inclusive end positions).
+type histogramRegion struct {
+	beginA int
+	endA   int
+	beginB int
+	endB   int
+}
+
+type histogramIndex struct {
+	a         []int
+	first     map[int]int
+	count     map[int]int
+	next      []int
+	cnt       int
+	hasCommon bool
+}
+
+func newHistogramIndex(a []int) *histogramIndex {
+	index := &histogramIndex{
+		a:     a,
+		first: make(map[int]int),
+		count: make(map[int]int),
+		next:  make([]int, len(a)),
+	}
+	for x := len(a) - 1; x >= 0; x-- {
+		id := a[x]
+		if first, ok := index.first[id]; ok {
+			index.next[x] = first
+		} else {
+			index.next[x] = -1
+		}
+		index.first[id] = x
+		index.count[id]++
+	}
+	return index
+}
+
+func (index *histogramIndex) findLCS(b []int) (histogramRegion, bool, bool) {
+	region := histogramRegion{}
+	found := false
+	index.cnt = histogramMaxChain + 1
+	for y := 0; y < len(b); {
+		y = index.tryLCS(b, y, &region, &found)
+	}
+	fallback := index.hasCommon && histogramMaxChain < index.cnt
+	return region, found, fallback
+}
+
+func (index *histogramIndex) tryLCS(b []int, y int, region *histogramRegion, found *bool) int {
+	a := index.a
+	next := y + 1
+	id := b[y]
+	x, ok := index.first[id]
+	if !ok {
+		return next
+	}
+	count := index.count[id]
+	index.hasCommon = true
+	if count > index.cnt {
+		return next
+	}
+	for {
+		nextX := index.next[x]
+		beginA, endA := x, x
+		beginB, endB := y, y
+		rc := count
+		for beginA > 0 && beginB > 0 && a[beginA-1] == b[beginB-1] {
+			beginA--
+			beginB--
+			if rc > 1 {
+				rc = min(rc, index.count[a[beginA]])
+			}
+		}
+		for endA+1 < len(a) && endB+1 < len(b) && a[endA+1] == b[endB+1] {
+			endA++
+			endB++
+			if rc > 1 {
+				rc = min(rc, index.count[a[endA]])
+			}
+		}
+		if next <= endB {
+			next = endB + 1
+		}
+		if region.endA-region.beginA < endA-beginA || rc < index.cnt {
+			*region = histogramRegion{beginA: beginA, endA: endA, beginB: beginB, endB: endB}
+			*found = true
+			index.cnt = rc
+		}
+		for nextX != -1 && nextX <= endA {
+			nextX = index.next[nextX]
+		}
+		if nextX == -1 {
+			return next
+		}
+		x = nextX
+	}
+}
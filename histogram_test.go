@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+// The expected outputs in testdata/histogram have been generated using:
+//
+//	git diff --no-index --histogram --no-indent-heuristic <name>_l.txt <name>_r.txt
+func TestHistogram(t *testing.T) {
+	expectedFiles, err := filepath.Glob("testdata/histogram/*.diff")
+	require.NoError(t, err)
+	require.NotEmpty(t, expectedFiles)
+	for _, expectedFile := range expectedFiles {
+		name := strings.TrimSuffix(expectedFile, ".diff")
+		t.Run(filepath.Base(name), func(t *testing.T) {
+			expected, err := os.ReadFile(expectedFile)
+			require.NoError(t, err)
+			result, err := diff.DiffFiles(name+"_l.txt", name+"_r.txt", diff.WithAlgorithm(diff.HistogramAlgorithm))
+			require.NoError(t, err)
+			output := &strings.Builder{}
+			diff.NewPrinter(output, diff.WithAnsi(false), diff.WithUnifiedFormatter(diff.DefaultUnifiedContext)).Print(result)
+			require.Equal(t, testNormalizeHunks(string(expected)), testNormalizeHunks(testStripHeader(output.String())))
+		})
+	}
+}
+
+func TestHistogramFiles(t *testing.T) {
+	result, err := diff.DiffFiles(leftFileName, rightFileName, diff.WithAlgorithm(diff.HistogramAlgorithm))
+	require.NoError(t, err)
+	require.Len(t, result.Diffs, 39)
+	require.Equal(t, 13, testEditCount(result))
+}
+
+func testStripHeader(unified string) string {
+	return unified[strings.Index(unified, "@@"):]
+}
+
+var testHunkRangePattern = regexp.MustCompile(`(?m)^@@ -(\d+)(,\d+)? \+(\d+)(,\d+)? @@$`)
+
+// testNormalizeHunks normalizes the hunk ranges by adding the
+// optional line count (git omits it for single line ranges).
+func testNormalizeHunks(unified string) string {
+	return testHunkRangePattern.ReplaceAllStringFunc(unified, func(hunk string) string {
+		match := testHunkRangePattern.FindStringSubmatch(hunk)
+		leftCount := strings.TrimPrefix(match[2], ",")
+		if leftCount == "" {
+			leftCount = "1"
+		}
+		rightCount := strings.TrimPrefix(match[4], ",")
+		if rightCount == "" {
+			rightCount = "1"
+		}
+		return fmt.Sprintf("@@ -%s,%s +%s,%s @@", match[1], leftCount, match[3], rightCount)
+	})
+}
@@ -0,0 +1,153 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"iter"
+)
+
+// Hunk represents a group of changed lines together with
+// their surrounding context lines.
+type Hunk struct {
+	// LeftStart contains the line number (1-based) of the hunk's first
+	// left line. If the hunk contains no left lines, it contains the line
+	// number of the left line preceding the hunk (0 for the beginning).
+	LeftStart int
+	// LeftLength contains the number of left lines in the hunk.
+	LeftLength int
+	// RightStart contains the line number (1-based) of the hunk's first
+	// right line. If the hunk contains no right lines, it contains the line
+	// number of the right line preceding the hunk (0 for the beginning).
+	RightStart int
+	// RightLength contains the number of right lines in the hunk.
+	RightLength int
+	// Diffs contains the diff results of all lines in the hunk.
+	Diffs []LineDiff
+}
+
+// Hunks groups the changed lines of the diff result in hunks.
+//
+// Each hunk is surrounded by up to context equal lines. Changes separated by
+// less or equal than 2*context equal lines are merged into one hunk. Ignored
+// changes (see [LineDiff.Ignored]) are only merged into a hunk, if they are
+// separated by less than context equal lines. Hunks consisting of ignored
+// changes only are skipped. The hunk ranges are derived from the line numbers
+// recorded in the diff result. Missing line numbers (e.g. in case of a
+// manually created diff result) are determined by counting the lines.
+func (r *Result) Hunks(context int) iter.Seq[Hunk] {
+	checkedContext := max(context, 0)
+	return func(yield func(Hunk) bool) {
+		numbers := &lineNumbers{diffs: r.Diffs}
+		index := 0
+		for index < len(r.Diffs) {
+			if r.Diffs[index].Op == EqlOp {
+				index++
+				continue
+			}
+			start := max(index-checkedContext, 0)
+			changeEnd, ignored := r.changeEnd(index)
+			for next := changeEnd; next < len(r.Diffs); {
+				if r.Diffs[next].Op == EqlOp {
+					next++
+					continue
+				}
+				nextEnd, nextIgnored := r.changeEnd(next)
+				threshold := 2 * checkedContext
+				if nextIgnored {
+					threshold = checkedContext - 1
+				}
+				if next-changeEnd > threshold {
+					break
+				}
+				changeEnd = nextEnd
+				ignored = ignored && nextIgnored
+				next = nextEnd
+			}
+			if ignored {
+				index = changeEnd
+				continue
+			}
+			lastChange := changeEnd - 1
+			end := min(lastChange+checkedContext+1, len(r.Diffs))
+			hunk := Hunk{Diffs: r.Diffs[start:end]}
+			numbers.advance(start)
+			hunk.LeftStart = numbers.left
+			hunk.RightStart = numbers.right
+			for range hunk.Diffs {
+				leftNumber, rightNumber := numbers.next()
+				if leftNumber > 0 {
+					if hunk.LeftLength == 0 {
+						hunk.LeftStart = leftNumber
+					}
+					hunk.LeftLength++
+				}
+				if rightNumber > 0 {
+					if hunk.RightLength == 0 {
+						hunk.RightStart = rightNumber
+					}
+					hunk.RightLength++
+				}
+			}
+			if !yield(hunk) {
+				return
+			}
+			index = end
+		}
+	}
+}
+
+// changeEnd determines the end of the change starting at the given index and
+// whether all of its lines are ignored.
+func (r *Result) changeEnd(index int) (int, bool) {
+	ignored := true
+	end := index
+	for ; end < len(r.Diffs) && r.Diffs[end].Op != EqlOp; end++ {
+		ignored = ignored && r.Diffs[end].Ignored
+	}
+	return end, ignored
+}
+
+// lineNumbers determines the line numbers of the diff entries in sequence.
+// If an entry has no recorded line number, the line number is counted.
+type lineNumbers struct {
+	diffs []LineDiff
+	index int
+	left  int
+	right int
+}
+
+// advance skips the diff entries up to the given index. Afterwards left and
+// right contain the line numbers of the last lines before this index.
+func (n *lineNumbers) advance(index int) {
+	for n.index < index {
+		n.next()
+	}
+}
+
+// next determines the left and right line numbers of the next diff entry
+// (0 if the entry has no left respectively right line).
+func (n *lineNumbers) next() (int, int) {
+	diff := n.diffs[n.index]
+	n.index++
+	leftNumber := 0
+	rightNumber := 0
+	if diff.Op != AddOp {
+		leftNumber = diff.LeftNumber
+		if leftNumber == 0 {
+			leftNumber = n.left + 1
+		}
+		n.left = leftNumber
+	}
+	if diff.Op != DelOp {
+		rightNumber = diff.RightNumber
+		if rightNumber == 0 {
+			rightNumber = n.right + 1
+		}
+		n.right = rightNumber
+	}
+	return leftNumber, rightNumber
+}
@@ -0,0 +1,94 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestHunks(t *testing.T) {
+	result, err := diff.DiffFiles(leftFileName, rightFileName)
+	require.NoError(t, err)
+	hunks := slices.Collect(result.Hunks(diff.DefaultUnifiedContext))
+	require.Len(t, hunks, 2)
+	testRequireHunk(t, hunks[0], 1, 3, 1, 13)
+	testRequireHunk(t, hunks[1], 24, 6, 34, 3)
+	hunks = slices.Collect(result.Hunks(0))
+	require.Len(t, hunks, 2)
+	testRequireHunk(t, hunks[0], 0, 0, 1, 10)
+	testRequireHunk(t, hunks[1], 27, 3, 36, 0)
+	hunks = slices.Collect(result.Hunks(15))
+	require.Len(t, hunks, 1)
+	testRequireHunk(t, hunks[0], 1, 29, 1, 36)
+}
+
+func TestHunksMerge(t *testing.T) {
+	left := []string{"a\n", "b\n", "c\n", "d\n", "e\n", "f\n", "g\n"}
+	right := []string{"A\n", "b\n", "c\n", "d\n", "e\n", "F\n", "g\n"}
+	result := diff.DiffLines(left, right)
+	hunks := slices.Collect(result.Hunks(2))
+	require.Len(t, hunks, 1)
+	testRequireHunk(t, hunks[0], 1, 7, 1, 7)
+	hunks = slices.Collect(result.Hunks(1))
+	require.Len(t, hunks, 2)
+	testRequireHunk(t, hunks[0], 1, 2, 1, 2)
+	testRequireHunk(t, hunks[1], 5, 3, 5, 3)
+	for range result.Hunks(1) {
+		break
+	}
+}
+
+func TestHunksEqual(t *testing.T) {
+	result, err := diff.DiffFiles(leftFileName, leftFileName)
+	require.NoError(t, err)
+	require.Empty(t, slices.Collect(result.Hunks(diff.DefaultUnifiedContext)))
+}
+
+func TestHunksWithoutNumbers(t *testing.T) {
+	result, err := diff.DiffFiles(leftFileName, rightFileName)
+	require.NoError(t, err)
+	manual := &diff.Result{LeftName: result.LeftName, RightName: result.RightName}
+	for _, lineDiff := range result.Diffs {
+		manual.Diffs = append(manual.Diffs, diff.LineDiff{Op: lineDiff.Op, Line: lineDiff.Line})
+	}
+	for _, context := range []int{0, diff.DefaultUnifiedContext} {
+		hunks := slices.Collect(result.Hunks(context))
+		manualHunks := slices.Collect(manual.Hunks(context))
+		require.Len(t, manualHunks, len(hunks))
+		for index, hunk := range hunks {
+			testRequireHunk(t, manualHunks[index], hunk.LeftStart, hunk.LeftLength, hunk.RightStart, hunk.RightLength)
+		}
+	}
+	output := &strings.Builder{}
+	manualOutput := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithUnifiedFormatter(diff.DefaultUnifiedContext)).Print(result)
+	diff.NewPrinter(manualOutput, diff.WithAnsi(false), diff.WithUnifiedFormatter(diff.DefaultUnifiedContext)).Print(manual)
+	require.Equal(t, output.String(), manualOutput.String())
+}
+
+func testRequireHunk(t *testing.T, hunk diff.Hunk, leftStart int, leftLength int, rightStart int, rightLength int) {
+	require.Equal(t, leftStart, hunk.LeftStart)
+	require.Equal(t, leftLength, hunk.LeftLength)
+	require.Equal(t, rightStart, hunk.RightStart)
+	require.Equal(t, rightLength, hunk.RightLength)
+	require.Len(t, hunk.Diffs, leftLength+testHunkAdds(hunk))
+}
+
+func testHunkAdds(hunk diff.Hunk) int {
+	adds := 0
+	for _, lineDiff := range hunk.Diffs {
+		if lineDiff.Op == diff.AddOp {
+			adds++
+		}
+	}
+	return adds
+}
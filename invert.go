@@ -0,0 +1,74 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+// Invert creates the inverted diff result, describing the changes
+// from the right side back to the left side.
+//
+// Left and right names, line numbers and newline states are swapped,
+// added lines become deleted lines and vice versa. Within each block of
+// changed lines, the deleted lines are placed before the added lines.
+func (r *Result) Invert() *Result {
+	return &Result{
+		LeftName:       r.RightName,
+		RightName:      r.LeftName,
+		Diffs:          invertDiffs(r.Diffs),
+		LeftNoNewline:  r.RightNoNewline,
+		RightNoNewline: r.LeftNoNewline,
+		Heuristic:      r.Heuristic,
+	}
+}
+
+// Invert creates the inverted hunk (see [Result.Invert]).
+func (h Hunk) Invert() Hunk {
+	return Hunk{
+		LeftStart:   h.RightStart,
+		LeftLength:  h.RightLength,
+		RightStart:  h.LeftStart,
+		RightLength: h.LeftLength,
+		Diffs:       invertDiffs(h.Diffs),
+	}
+}
+
+// Invert creates the inverted patch (see [Result.Invert]).
+func (p *Patch) Invert() *Patch {
+	hunks := make([]Hunk, 0, len(p.Hunks))
+	for _, hunk := range p.Hunks {
+		hunks = append(hunks, hunk.Invert())
+	}
+	return &Patch{
+		LeftName:  p.RightName,
+		LeftInfo:  p.RightInfo,
+		RightName: p.LeftName,
+		RightInfo: p.LeftInfo,
+		Hunks:     hunks,
+	}
+}
+
+func invertDiffs(diffs []LineDiff) []LineDiff {
+	inverted := make([]LineDiff, 0, len(diffs))
+	adds := make([]LineDiff, 0)
+	for _, diff := range diffs {
+		switch diff.Op {
+		case EqlOp:
+			inverted = append(inverted, adds...)
+			adds = adds[:0]
+			invertedDiff := LineDiff{Op: EqlOp, Line: diff.Line, LeftNumber: diff.RightNumber, RightNumber: diff.LeftNumber}
+			if diff.RightLine != "" {
+				invertedDiff.Line = diff.RightLine
+				invertedDiff.RightLine = diff.Line
+			}
+			inverted = append(inverted, invertedDiff)
+		case AddOp:
+			inverted = append(inverted, LineDiff{Op: DelOp, Line: diff.Line, LeftNumber: diff.RightNumber, Move: diff.Move, Ignored: diff.Ignored})
+		case DelOp:
+			// deleted lines become added lines and are deferred until the end of the block
+			adds = append(adds, LineDiff{Op: AddOp, Line: diff.Line, RightNumber: diff.LeftNumber, Move: diff.Move, Ignored: diff.Ignored})
+		}
+	}
+	return append(inverted, adds...)
+}
@@ -0,0 +1,78 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestResultInvert(t *testing.T) {
+	result, err := diff.DiffFiles(leftFileName, rightFileName)
+	require.NoError(t, err)
+	inverted := result.Invert()
+	require.Equal(t, rightFileName, inverted.LeftName)
+	require.Equal(t, leftFileName, inverted.RightName)
+	require.Len(t, inverted.Diffs, len(result.Diffs))
+	for _, lineDiff := range result.Diffs {
+		require.Contains(t, inverted.Diffs, testInvertDiff(lineDiff))
+	}
+	require.Equal(t, result, inverted.Invert())
+	hunks := slices.Collect(result.Hunks(diff.DefaultUnifiedContext))
+	invertedHunks := slices.Collect(inverted.Hunks(diff.DefaultUnifiedContext))
+	require.Len(t, invertedHunks, len(hunks))
+	for index, hunk := range hunks {
+		require.Equal(t, hunk.Invert(), invertedHunks[index])
+	}
+}
+
+func TestResultInvertNoNewline(t *testing.T) {
+	result := diff.DiffLines([]string{"a\n", "b"}, []string{"a\n", "c\n"})
+	inverted := result.Invert()
+	require.False(t, inverted.LeftNoNewline)
+	require.True(t, inverted.RightNoNewline)
+	require.Equal(t, []diff.LineDiff{
+		{Op: diff.EqlOp, Line: "a\n", LeftNumber: 1, RightNumber: 1},
+		{Op: diff.DelOp, Line: "c\n", LeftNumber: 2},
+		{Op: diff.AddOp, Line: "b", RightNumber: 2},
+	}, inverted.Diffs)
+}
+
+func TestResultInvertRightLine(t *testing.T) {
+	result := diff.DiffLines([]string{"a\n"}, []string{"A\n"}, diff.WithIgnoreCase())
+	require.Equal(t, []diff.LineDiff{
+		{Op: diff.EqlOp, Line: "A\n", RightLine: "a\n", LeftNumber: 1, RightNumber: 1},
+	}, result.Invert().Diffs)
+}
+
+func TestPatchInvert(t *testing.T) {
+	result, err := diff.DiffFiles(leftFileName, rightFileName)
+	require.NoError(t, err)
+	left := testReadLines(t, leftFileName)
+	right := testReadLines(t, rightFileName)
+	patch := result.Patch(diff.DefaultUnifiedContext)
+	applied, err := diff.ApplyLines(right, patch.Invert(), diff.WithStrict())
+	require.NoError(t, err)
+	require.Equal(t, left, applied.Lines)
+	applied, err = diff.ApplyLines(right, patch, diff.WithReverse(), diff.WithStrict())
+	require.NoError(t, err)
+	require.Equal(t, left, applied.Lines)
+	require.Equal(t, patch, patch.Invert().Invert())
+}
+
+func testInvertDiff(lineDiff diff.LineDiff) diff.LineDiff {
+	switch lineDiff.Op {
+	case diff.AddOp:
+		return diff.LineDiff{Op: diff.DelOp, Line: lineDiff.Line, LeftNumber: lineDiff.RightNumber}
+	case diff.DelOp:
+		return diff.LineDiff{Op: diff.AddOp, Line: lineDiff.Line, RightNumber: lineDiff.LeftNumber}
+	}
+	return diff.LineDiff{Op: diff.EqlOp, Line: lineDiff.Line, LeftNumber: lineDiff.RightNumber, RightNumber: lineDiff.LeftNumber}
+}
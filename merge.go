@@ -0,0 +1,526 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"context"
+	"io"
+	"os"
+	"slices"
+)
+
+// ConflictStyle defines how conflicts are marked in the merged lines.
+type ConflictStyle int
+
+const (
+	// MergeConflictStyle marks conflicts with our and their lines (git's merge style, default).
+	// Leading and trailing lines common to our and their lines are moved out of the conflict.
+	MergeConflictStyle ConflictStyle = 0
+	// Diff3ConflictStyle marks conflicts with our, base and their lines (git's diff3 style).
+	Diff3ConflictStyle ConflictStyle = 1
+	// ZDiff3ConflictStyle marks conflicts like [Diff3ConflictStyle], but moves leading and
+	// trailing lines common to our and their lines out of the conflict (git's zdiff3 style).
+	ZDiff3ConflictStyle ConflictStyle = 2
+)
+
+// MergeStrategy defines how conflicts are resolved.
+type MergeStrategy int
+
+const (
+	// ConflictStrategy does not resolve conflicts, but marks them (default).
+	ConflictStrategy MergeStrategy = 0
+	// OursStrategy resolves conflicts by taking our lines.
+	OursStrategy MergeStrategy = 1
+	// TheirsStrategy resolves conflicts by taking their lines.
+	TheirsStrategy MergeStrategy = 2
+	// UnionStrategy resolves conflicts by taking our lines followed by their lines.
+	UnionStrategy MergeStrategy = 3
+)
+
+// ChunkKind defines the kind of changes within a merge chunk.
+type ChunkKind int
+
+const (
+	// UnchangedChunk indicates, the lines are unchanged in all versions.
+	UnchangedChunk ChunkKind = 0
+	// OursChunk indicates, only our lines have been changed.
+	OursChunk ChunkKind = 1
+	// TheirsChunk indicates, only their lines have been changed.
+	TheirsChunk ChunkKind = 2
+	// BothChunk indicates, our and their lines have been changed the same way.
+	BothChunk ChunkKind = 3
+	// ConflictChunk indicates, our and their lines have been changed differently.
+	ConflictChunk ChunkKind = 4
+)
+
+// Conflict markers as used by git.
+const (
+	conflictOursMarker   = "<<<<<<<"
+	conflictBaseMarker   = "|||||||"
+	conflictSepMarker    = "======="
+	conflictTheirsMarker = ">>>>>>>"
+)
+
+// DefaultOursLabel is used to label our lines in conflicts
+// in case no specific label has been given.
+const DefaultOursLabel = "ours"
+
+// DefaultBaseLabel is used to label the base lines in conflicts
+// in case no specific label has been given.
+const DefaultBaseLabel = "base"
+
+// DefaultTheirsLabel is used to label their lines in conflicts
+// in case no specific label has been given.
+const DefaultTheirsLabel = "theirs"
+
+// MergeChunk represents a group of base lines together with
+// the corresponding lines of our and their version.
+type MergeChunk struct {
+	// Kind indicates the kind of changes within this chunk.
+	Kind ChunkKind
+	// BaseStart contains the line number (1-based) of the chunk's first
+	// base line. If the chunk contains no base lines, it contains the line
+	// number of the base line preceding the chunk (0 for the beginning).
+	BaseStart int
+	// Base contains the base lines of this chunk.
+	Base []string
+	// OursStart contains the line number (1-based) of the chunk's first
+	// line of our version (see BaseStart).
+	OursStart int
+	// Ours contains the lines of our version of this chunk.
+	Ours []string
+	// TheirsStart contains the line number (1-based) of the chunk's first
+	// line of their version (see BaseStart).
+	TheirsStart int
+	// Theirs contains the lines of their version of this chunk.
+	Theirs []string
+}
+
+// MergeConflict represents a conflict encountered during a merge.
+type MergeConflict struct {
+	// Line contains the line number (1-based) of the conflict's first
+	// line within the merged lines.
+	Line int
+	// Length contains the number of merged lines (including any
+	// conflict markers) representing the conflict.
+	Length int
+	// Chunk contains the conflicting chunk.
+	Chunk MergeChunk
+	// Resolved indicates, the conflict has been resolved
+	// by the merge strategy.
+	Resolved bool
+}
+
+// MergeResult contains the result of a Merge operation.
+type MergeResult struct {
+	// OursLabel contains the label of our version.
+	OursLabel string
+	// BaseLabel contains the label of the base version.
+	BaseLabel string
+	// TheirsLabel contains the label of their version.
+	TheirsLabel string
+	// Lines contains the merged lines.
+	Lines []string
+	// Chunks contains all chunks of the three versions.
+	Chunks []MergeChunk
+	// Conflicts contains all conflicts encountered during the merge.
+	Conflicts []MergeConflict
+}
+
+// HasConflicts checks whether the merge result contains unresolved conflicts.
+func (r *MergeResult) HasConflicts() bool {
+	for _, conflict := range r.Conflicts {
+		if !conflict.Resolved {
+			return true
+		}
+	}
+	return false
+}
+
+// Merger type supports configurable three-way merging of line based content.
+type Merger struct {
+	oursLabel   string
+	baseLabel   string
+	theirsLabel string
+	style       ConflictStyle
+	strategy    MergeStrategy
+	diffOpts    []DiffOption
+}
+
+// MergeFiles runs a merge operation on the three given file names.
+//
+// The file names are used to label the conflicts, unless explicit
+// labels have been set via [WithOursLabel], [WithBaseLabel] or [WithTheirsLabel].
+func (m *Merger) MergeFiles(baseName string, oursName string, theirsName string) (*MergeResult, error) {
+	return m.MergeFilesContext(context.Background(), baseName, oursName, theirsName)
+}
+
+// MergeFilesContext runs a merge operation on the three given file names
+// using the given context.
+//
+// If the context is canceled while the merge operation is running, the
+// context's error is returned.
+func (m *Merger) MergeFilesContext(ctx context.Context, baseName string, oursName string, theirsName string) (*MergeResult, error) {
+	base, err := os.Open(baseName)
+	if err != nil {
+		return nil, err
+	}
+	defer base.Close()
+	ours, err := os.Open(oursName)
+	if err != nil {
+		return nil, err
+	}
+	defer ours.Close()
+	theirs, err := os.Open(theirsName)
+	if err != nil {
+		return nil, err
+	}
+	defer theirs.Close()
+	return m.mergeReaders(ctx, base, ours, theirs, m.label(m.baseLabel, baseName), m.label(m.oursLabel, oursName), m.label(m.theirsLabel, theirsName))
+}
+
+// MergeLines runs a merge operation on the three given string arrays.
+func (m *Merger) MergeLines(base []string, ours []string, theirs []string) *MergeResult {
+	// a background context is never canceled and hence there is no error
+	result, _ := m.MergeLinesContext(context.Background(), base, ours, theirs)
+	return result
+}
+
+// MergeLinesContext runs a merge operation on the three given string arrays
+// using the given context.
+//
+// If the context is canceled while the merge operation is running, the
+// context's error is returned.
+func (m *Merger) MergeLinesContext(ctx context.Context, base []string, ours []string, theirs []string) (*MergeResult, error) {
+	return m.run(ctx, base, ours, theirs, m.label(m.baseLabel, DefaultBaseLabel), m.label(m.oursLabel, DefaultOursLabel), m.label(m.theirsLabel, DefaultTheirsLabel))
+}
+
+// Merge runs a merge operation on the three given reader's contents.
+func (m *Merger) Merge(base io.Reader, ours io.Reader, theirs io.Reader) (*MergeResult, error) {
+	return m.MergeContext(context.Background(), base, ours, theirs)
+}
+
+// MergeContext runs a merge operation on the three given reader's contents
+// using the given context.
+//
+// If the context is canceled while the merge operation is running, the
+// context's error is returned.
+func (m *Merger) MergeContext(ctx context.Context, base io.Reader, ours io.Reader, theirs io.Reader) (*MergeResult, error) {
+	return m.mergeReaders(ctx, base, ours, theirs, m.label(m.baseLabel, DefaultBaseLabel), m.label(m.oursLabel, DefaultOursLabel), m.label(m.theirsLabel, DefaultTheirsLabel))
+}
+
+func (m *Merger) label(label string, defaultLabel string) string {
+	if label == "" {
+		return defaultLabel
+	}
+	return label
+}
+
+func (m *Merger) mergeReaders(ctx context.Context, base io.Reader, ours io.Reader, theirs io.Reader, baseLabel string, oursLabel string, theirsLabel string) (*MergeResult, error) {
+	baseLines, err := readLines(base)
+	if err != nil {
+		return nil, err
+	}
+	oursLines, err := readLines(ours)
+	if err != nil {
+		return nil, err
+	}
+	theirsLines, err := readLines(theirs)
+	if err != nil {
+		return nil, err
+	}
+	return m.run(ctx, baseLines, oursLines, theirsLines, baseLabel, oursLabel, theirsLabel)
+}
+
+func (m *Merger) run(ctx context.Context, base []string, ours []string, theirs []string, baseLabel string, oursLabel string, theirsLabel string) (*MergeResult, error) {
+	chunks, err := mergeChunks(ctx, NewDiffer(m.diffOpts...), base, ours, theirs)
+	if err != nil {
+		return nil, err
+	}
+	result := &MergeResult{
+		OursLabel:   oursLabel,
+		BaseLabel:   baseLabel,
+		TheirsLabel: theirsLabel,
+		Lines:       make([]string, 0, len(base)),
+		Chunks:      chunks,
+		Conflicts:   make([]MergeConflict, 0),
+	}
+	for _, chunk := range chunks {
+		switch chunk.Kind {
+		case UnchangedChunk, OursChunk, BothChunk:
+			result.Lines = append(result.Lines, chunk.Ours...)
+		case TheirsChunk:
+			result.Lines = append(result.Lines, chunk.Theirs...)
+		case ConflictChunk:
+			m.mergeConflict(result, chunk)
+		}
+	}
+	return result, nil
+}
+
+func (m *Merger) mergeConflict(result *MergeResult, chunk MergeChunk) {
+	conflict := MergeConflict{Line: len(result.Lines) + 1, Chunk: chunk, Resolved: m.strategy != ConflictStrategy}
+	switch m.strategy {
+	case OursStrategy:
+		result.Lines = append(result.Lines, chunk.Ours...)
+	case TheirsStrategy:
+		result.Lines = append(result.Lines, chunk.Theirs...)
+	case UnionStrategy:
+		result.Lines = append(result.Lines, terminateLines(chunk.Ours, len(chunk.Theirs) > 0)...)
+		result.Lines = append(result.Lines, chunk.Theirs...)
+	default:
+		ours := chunk.Ours
+		theirs := chunk.Theirs
+		var suffix []string
+		// the diff3 style shows the base lines and hence retains the complete conflict
+		if m.style != Diff3ConflictStyle {
+			prefixLength, suffixLength := commonPrefixSuffix(ours, theirs, equalComparable)
+			result.Lines = append(result.Lines, ours[:prefixLength]...)
+			suffix = ours[len(ours)-suffixLength:]
+			ours = ours[prefixLength : len(ours)-suffixLength]
+			theirs = theirs[prefixLength : len(theirs)-suffixLength]
+			conflict.Line += prefixLength
+		}
+		result.Lines = append(result.Lines, conflictMarker(conflictOursMarker, result.OursLabel))
+		result.Lines = append(result.Lines, terminateLines(ours, true)...)
+		if m.style != MergeConflictStyle {
+			result.Lines = append(result.Lines, conflictMarker(conflictBaseMarker, result.BaseLabel))
+			result.Lines = append(result.Lines, terminateLines(chunk.Base, true)...)
+		}
+		result.Lines = append(result.Lines, conflictSepMarker+"\n")
+		result.Lines = append(result.Lines, terminateLines(theirs, true)...)
+		result.Lines = append(result.Lines, conflictMarker(conflictTheirsMarker, result.TheirsLabel))
+		conflict.Length = len(result.Lines) + 1 - conflict.Line
+		result.Lines = append(result.Lines, suffix...)
+		result.Conflicts = append(result.Conflicts, conflict)
+		return
+	}
+	conflict.Length = len(result.Lines) + 1 - conflict.Line
+	result.Conflicts = append(result.Conflicts, conflict)
+}
+
+func conflictMarker(marker string, label string) string {
+	if label == "" {
+		return marker + "\n"
+	}
+	return marker + " " + label + "\n"
+}
+
+// terminateLines makes sure the last line is terminated by a newline,
+// in case it is followed by further lines.
+func terminateLines(lines []string, followed bool) []string {
+	if !followed || !hasNoNewline(lines) {
+		return lines
+	}
+	terminated := slices.Clone(lines)
+	terminated[len(terminated)-1] += "\n"
+	return terminated
+}
+
+// mergeChunks splits the three versions in chunks by diffing our and their
+// version against the base version. Base lines which are kept in both
+// versions synchronize the chunks.
+func mergeChunks(ctx context.Context, differ *Differ, base []string, ours []string, theirs []string) ([]MergeChunk, error) {
+	oursResult, err := differ.DiffLinesContext(ctx, base, ours)
+	if err != nil {
+		return nil, err
+	}
+	theirsResult, err := differ.DiffLinesContext(ctx, base, theirs)
+	if err != nil {
+		return nil, err
+	}
+	oursMatch := mergeMatches(oursResult, len(base))
+	theirsMatch := mergeMatches(theirsResult, len(base))
+	chunks := make([]MergeChunk, 0)
+	baseIndex, oursIndex, theirsIndex := 0, 0, 0
+	for {
+		unchanged := 0
+		for baseIndex+unchanged < len(base) && oursMatch[baseIndex+unchanged] == oursIndex+unchanged && theirsMatch[baseIndex+unchanged] == theirsIndex+unchanged {
+			unchanged++
+		}
+		if unchanged > 0 {
+			chunks = append(chunks, newMergeChunk(UnchangedChunk, base, baseIndex, baseIndex+unchanged, ours, oursIndex, oursIndex+unchanged, theirs, theirsIndex, theirsIndex+unchanged))
+			baseIndex += unchanged
+			oursIndex += unchanged
+			theirsIndex += unchanged
+		}
+		if baseIndex == len(base) && oursIndex == len(ours) && theirsIndex == len(theirs) {
+			break
+		}
+		baseEnd := baseIndex
+		for baseEnd < len(base) && (oursMatch[baseEnd] < 0 || theirsMatch[baseEnd] < 0) {
+			baseEnd++
+		}
+		oursEnd, theirsEnd := len(ours), len(theirs)
+		if baseEnd < len(base) {
+			oursEnd, theirsEnd = oursMatch[baseEnd], theirsMatch[baseEnd]
+		}
+		chunk := newMergeChunk(ConflictChunk, base, baseIndex, baseEnd, ours, oursIndex, oursEnd, theirs, theirsIndex, theirsEnd)
+		switch {
+		case slices.Equal(chunk.Ours, chunk.Base):
+			chunk.Kind = TheirsChunk
+		case slices.Equal(chunk.Theirs, chunk.Base):
+			chunk.Kind = OursChunk
+		case slices.Equal(chunk.Ours, chunk.Theirs):
+			chunk.Kind = BothChunk
+		}
+		chunks = append(chunks, chunk)
+		baseIndex, oursIndex, theirsIndex = baseEnd, oursEnd, theirsEnd
+	}
+	return chunks, nil
+}
+
+// mergeMatches maps each base line to the index of the matching line
+// of the diffed version (or -1 if the base line has been deleted).
+func mergeMatches(result *Result, n int) []int {
+	matches := make([]int, n)
+	for _, diff := range result.Diffs {
+		switch diff.Op {
+		case EqlOp:
+			matches[diff.LeftNumber-1] = diff.RightNumber - 1
+		case DelOp:
+			matches[diff.LeftNumber-1] = -1
+		}
+	}
+	return matches
+}
+
+func newMergeChunk(kind ChunkKind, base []string, baseStart int, baseEnd int, ours []string, oursStart int, oursEnd int, theirs []string, theirsStart int, theirsEnd int) MergeChunk {
+	return MergeChunk{
+		Kind:        kind,
+		BaseStart:   mergeChunkStart(baseStart, baseEnd),
+		Base:        base[baseStart:baseEnd],
+		OursStart:   mergeChunkStart(oursStart, oursEnd),
+		Ours:        ours[oursStart:oursEnd],
+		TheirsStart: mergeChunkStart(theirsStart, theirsEnd),
+		Theirs:      theirs[theirsStart:theirsEnd],
+	}
+}
+
+func mergeChunkStart(start int, end int) int {
+	if start == end {
+		return start
+	}
+	return start + 1
+}
+
+// MergeOption interface is used to configure a Merger instance.
+type MergeOption interface {
+	// Apply applies the options represented by this instance
+	// to the given Merger instance.
+	Apply(m *Merger)
+}
+
+// MergeOptionFunc typed functions are used to configure a Merger instance.
+type MergeOptionFunc func(*Merger)
+
+// Apply applies options to the given Merger instance.
+func (f MergeOptionFunc) Apply(m *Merger) {
+	f(m)
+}
+
+// WithOursLabel sets the label of our version used to mark conflicts.
+//
+// Per default the file name (for files) or [DefaultOursLabel] is used.
+func WithOursLabel(label string) MergeOption {
+	return MergeOptionFunc(func(m *Merger) {
+		m.oursLabel = label
+	})
+}
+
+// WithBaseLabel sets the label of the base version used to mark conflicts.
+//
+// Per default the file name (for files) or [DefaultBaseLabel] is used.
+func WithBaseLabel(label string) MergeOption {
+	return MergeOptionFunc(func(m *Merger) {
+		m.baseLabel = label
+	})
+}
+
+// WithTheirsLabel sets the label of their version used to mark conflicts.
+//
+// Per default the file name (for files) or [DefaultTheirsLabel] is used.
+func WithTheirsLabel(label string) MergeOption {
+	return MergeOptionFunc(func(m *Merger) {
+		m.theirsLabel = label
+	})
+}
+
+// WithConflictStyle sets the style used to mark conflicts.
+//
+// Per default [MergeConflictStyle] is used.
+func WithConflictStyle(style ConflictStyle) MergeOption {
+	return MergeOptionFunc(func(m *Merger) {
+		m.style = style
+	})
+}
+
+// WithMergeStrategy sets the strategy used to resolve conflicts.
+//
+// Per default [ConflictStrategy] is used, which leaves conflicts
+// unresolved and marks them in the merged lines.
+func WithMergeStrategy(strategy MergeStrategy) MergeOption {
+	return MergeOptionFunc(func(m *Merger) {
+		m.strategy = strategy
+	})
+}
+
+// WithDiffOptions sets the diff options used to diff our and their
+// version against the base version (e.g. [WithAlgorithm]).
+func WithDiffOptions(opts ...DiffOption) MergeOption {
+	return MergeOptionFunc(func(m *Merger) {
+		m.diffOpts = append(m.diffOpts, opts...)
+	})
+}
+
+// NewMerger creates a new Merger instance using the given merge options.
+func NewMerger(opts ...MergeOption) *Merger {
+	merger := &Merger{
+		style:    MergeConflictStyle,
+		strategy: ConflictStrategy,
+	}
+	for _, opt := range opts {
+		opt.Apply(merger)
+	}
+	return merger
+}
+
+// MergeFiles runs a merge operation on the three given file names
+// using the given merge options.
+func MergeFiles(baseName string, oursName string, theirsName string, opts ...MergeOption) (*MergeResult, error) {
+	return NewMerger(opts...).MergeFiles(baseName, oursName, theirsName)
+}
+
+// MergeFilesContext runs a merge operation on the three given file names
+// using the given merge options and context.
+func MergeFilesContext(ctx context.Context, baseName string, oursName string, theirsName string, opts ...MergeOption) (*MergeResult, error) {
+	return NewMerger(opts...).MergeFilesContext(ctx, baseName, oursName, theirsName)
+}
+
+// MergeLines runs a merge operation on the three given string arrays
+// using the given merge options.
+func MergeLines(base []string, ours []string, theirs []string, opts ...MergeOption) *MergeResult {
+	return NewMerger(opts...).MergeLines(base, ours, theirs)
+}
+
+// MergeLinesContext runs a merge operation on the three given string arrays
+// using the given merge options and context.
+func MergeLinesContext(ctx context.Context, base []string, ours []string, theirs []string, opts ...MergeOption) (*MergeResult, error) {
+	return NewMerger(opts...).MergeLinesContext(ctx, base, ours, theirs)
+}
+
+// Merge runs a merge operation on the three given reader's contents
+// using the given merge options.
+func Merge(base io.Reader, ours io.Reader, theirs io.Reader, opts ...MergeOption) (*MergeResult, error) {
+	return NewMerger(opts...).Merge(base, ours, theirs)
+}
+
+// MergeContext runs a merge operation on the three given reader's contents
+// using the given merge options and context.
+func MergeContext(ctx context.Context, base io.Reader, ours io.Reader, theirs io.Reader, opts ...MergeOption) (*MergeResult, error) {
+	return NewMerger(opts...).MergeContext(ctx, base, ours, theirs)
+}
@@ -0,0 +1,140 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+var testMergeBase = []string{"a\n", "b\n", "c\n", "d\n", "e\n", "f\n", "g\n"}
+
+func TestMergeClean(t *testing.T) {
+	ours := []string{"a\n", "B\n", "c\n", "d\n", "e\n", "f\n", "g\n"}
+	theirs := []string{"a\n", "b\n", "c\n", "d\n", "e\n", "F\n", "g\n", "h\n"}
+	result := diff.MergeLines(testMergeBase, ours, theirs)
+	require.False(t, result.HasConflicts())
+	require.Empty(t, result.Conflicts)
+	require.Equal(t, []string{"a\n", "B\n", "c\n", "d\n", "e\n", "F\n", "g\n", "h\n"}, result.Lines)
+	kinds := make([]diff.ChunkKind, 0, len(result.Chunks))
+	for _, chunk := range result.Chunks {
+		kinds = append(kinds, chunk.Kind)
+	}
+	require.Equal(t, []diff.ChunkKind{
+		diff.UnchangedChunk,
+		diff.OursChunk,
+		diff.UnchangedChunk,
+		diff.TheirsChunk,
+		diff.UnchangedChunk,
+		diff.TheirsChunk,
+	}, kinds)
+	require.Equal(t, diff.MergeChunk{
+		Kind:        diff.TheirsChunk,
+		BaseStart:   7,
+		Base:        []string{},
+		OursStart:   7,
+		Ours:        []string{},
+		TheirsStart: 8,
+		Theirs:      []string{"h\n"},
+	}, result.Chunks[5])
+}
+
+func TestMergeBoth(t *testing.T) {
+	changed := []string{"a\n", "b\n", "C\n", "d\n", "e\n", "f\n", "g\n"}
+	result := diff.MergeLines(testMergeBase, changed, changed)
+	require.False(t, result.HasConflicts())
+	require.Equal(t, changed, result.Lines)
+	require.Equal(t, diff.BothChunk, result.Chunks[1].Kind)
+}
+
+var testMergeOurs = []string{"a\n", "b\n", "x\n", "y1\n", "z\n", "f\n", "g\n"}
+var testMergeTheirs = []string{"a\n", "b\n", "x\n", "y2\n", "z\n", "f\n", "g\n"}
+
+func TestMergeConflictStyles(t *testing.T) {
+	result := diff.MergeLines(testMergeBase, testMergeOurs, testMergeTheirs, diff.WithOursLabel("HEAD"), diff.WithTheirsLabel("topic"))
+	require.True(t, result.HasConflicts())
+	require.Equal(t, []string{
+		"a\n", "b\n", "x\n",
+		"<<<<<<< HEAD\n", "y1\n", "=======\n", "y2\n", ">>>>>>> topic\n",
+		"z\n", "f\n", "g\n",
+	}, result.Lines)
+	require.Len(t, result.Conflicts, 1)
+	require.Equal(t, 4, result.Conflicts[0].Line)
+	require.Equal(t, 5, result.Conflicts[0].Length)
+	require.Equal(t, []string{"c\n", "d\n", "e\n"}, result.Conflicts[0].Chunk.Base)
+	require.False(t, result.Conflicts[0].Resolved)
+
+	result = diff.MergeLines(testMergeBase, testMergeOurs, testMergeTheirs, diff.WithConflictStyle(diff.Diff3ConflictStyle))
+	require.Equal(t, []string{
+		"a\n", "b\n",
+		"<<<<<<< ours\n", "x\n", "y1\n", "z\n",
+		"||||||| base\n", "c\n", "d\n", "e\n",
+		"=======\n", "x\n", "y2\n", "z\n",
+		">>>>>>> theirs\n",
+		"f\n", "g\n",
+	}, result.Lines)
+	require.Equal(t, 3, result.Conflicts[0].Line)
+	require.Equal(t, 13, result.Conflicts[0].Length)
+
+	result = diff.MergeLines(testMergeBase, testMergeOurs, testMergeTheirs, diff.WithConflictStyle(diff.ZDiff3ConflictStyle), diff.WithBaseLabel("merged common ancestors"))
+	require.Equal(t, []string{
+		"a\n", "b\n", "x\n",
+		"<<<<<<< ours\n", "y1\n",
+		"||||||| merged common ancestors\n", "c\n", "d\n", "e\n",
+		"=======\n", "y2\n",
+		">>>>>>> theirs\n",
+		"z\n", "f\n", "g\n",
+	}, result.Lines)
+}
+
+func TestMergeStrategies(t *testing.T) {
+	strategies := map[diff.MergeStrategy][]string{
+		diff.OursStrategy:   testMergeOurs,
+		diff.TheirsStrategy: testMergeTheirs,
+		diff.UnionStrategy:  {"a\n", "b\n", "x\n", "y1\n", "z\n", "x\n", "y2\n", "z\n", "f\n", "g\n"},
+	}
+	for strategy, expected := range strategies {
+		result := diff.MergeLines(testMergeBase, testMergeOurs, testMergeTheirs, diff.WithMergeStrategy(strategy))
+		require.False(t, result.HasConflicts())
+		require.Len(t, result.Conflicts, 1)
+		require.True(t, result.Conflicts[0].Resolved)
+		require.Equal(t, expected, result.Lines)
+	}
+}
+
+func TestMergeNoNewline(t *testing.T) {
+	base := []string{"a\n", "b"}
+	result := diff.MergeLines(base, []string{"a\n", "c"}, []string{"a\n", "d"})
+	require.Equal(t, []string{"a\n", "<<<<<<< ours\n", "c\n", "=======\n", "d\n", ">>>>>>> theirs\n"}, result.Lines)
+	result = diff.MergeLines(base, []string{"a\n", "c"}, []string{"a\n", "d"}, diff.WithMergeStrategy(diff.UnionStrategy))
+	require.Equal(t, []string{"a\n", "c\n", "d"}, result.Lines)
+}
+
+func TestMergeFiles(t *testing.T) {
+	result, err := diff.MergeFiles(leftFileName, leftFileName, rightFileName)
+	require.NoError(t, err)
+	require.Equal(t, leftFileName, result.OursLabel)
+	require.Equal(t, leftFileName, result.BaseLabel)
+	require.Equal(t, rightFileName, result.TheirsLabel)
+	require.False(t, result.HasConflicts())
+	require.Equal(t, testReadLines(t, rightFileName), result.Lines)
+
+	result, err = diff.Merge(strings.NewReader("a\nb\n"), strings.NewReader("a\nb\nc\n"), strings.NewReader("z\na\nb\n"), diff.WithDiffOptions(diff.WithAlgorithm(diff.HistogramAlgorithm)))
+	require.NoError(t, err)
+	require.Equal(t, []string{"z\n", "a\n", "b\n", "c\n"}, result.Lines)
+}
+
+func TestMergeContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err := diff.MergeFilesContext(ctx, leftFileName, leftFileName, rightFileName)
+	require.ErrorIs(t, err, context.Canceled)
+}
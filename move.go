@@ -0,0 +1,356 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MoveMode defines how moved lines are detected (see [Result.DetectMoves]).
+type MoveMode int
+
+const (
+	// NoMoves disables the detection of moved lines.
+	NoMoves MoveMode = 0
+	// PlainMoves marks any deleted line, which has been added somewhere else
+	// and vice versa (like git diff --color-moved=plain).
+	PlainMoves MoveMode = 1
+	// BlockMoves marks blocks of moved lines, consisting of at least 20
+	// alphanumeric characters (like git diff --color-moved=blocks).
+	BlockMoves MoveMode = 2
+	// ZebraMoves marks blocks of moved lines like BlockMoves, but also marks
+	// every second block of adjacent blocks as alternate block (like git diff
+	// --color-moved=zebra).
+	ZebraMoves MoveMode = 3
+	// DimmedZebraMoves marks blocks of moved lines like ZebraMoves, but
+	// additionally marks the lines in the inside of a block as dimmed (like
+	// git diff --color-moved=dimmed-zebra).
+	DimmedZebraMoves MoveMode = 4
+)
+
+// MoveFlag defines how white space is handled during the detection
+// of moved lines.
+type MoveFlag int
+
+const (
+	// MoveIgnoreSpaceAtEOLFlag ignores white space changes at line end.
+	MoveIgnoreSpaceAtEOLFlag MoveFlag = 1 << iota
+	// MoveIgnoreSpaceChangeFlag ignores changes in the amount of white space.
+	MoveIgnoreSpaceChangeFlag
+	// MoveIgnoreAllSpaceFlag ignores all white space.
+	MoveIgnoreAllSpaceFlag
+	// MoveAllowIndentationChangeFlag ignores the indentation of lines, as long
+	// as all lines of a moved block have been indented by the same amount.
+	MoveAllowIndentationChangeFlag
+)
+
+// moveMinAlnumCount defines the minimum number of alphanumeric
+// characters of a moved block.
+const moveMinAlnumCount int = 20
+
+// LineMove contains the move information of a line (see [Result.DetectMoves]).
+type LineMove struct {
+	// Block contains the number (1-based) of the moved block the line belongs
+	// to. 0 indicates, the line has not been moved.
+	Block int
+	// Number contains the line number of the line on the other side, this
+	// line has been matched with (the right line number in case of DelOp,
+	// the left line number in case of AddOp).
+	Number int
+	// Alternate indicates, the line belongs to an alternate block
+	// (see [ZebraMoves]).
+	Alternate bool
+	// Dimmed indicates, the line is in the inside of a moved block
+	// (see [DimmedZebraMoves]).
+	Dimmed bool
+}
+
+// Moved determines whether the line has been moved (see [Result.DetectMoves]).
+func (d LineDiff) Moved() bool {
+	return d.Move.Block != 0
+}
+
+// DetectMoves detects the added and deleted lines, which have actually been
+// moved from one position to another, using the given mode and white space
+// flags (like git diff --color-moved).
+//
+// The move information is recorded in the Move field of the added and
+// deleted lines. Any previously recorded move information is reset.
+func (r *Result) DetectMoves(mode MoveMode, flags ...MoveFlag) {
+	for index := range r.Diffs {
+		r.Diffs[index].Move = LineMove{}
+	}
+	if mode == NoMoves {
+		return
+	}
+	var moveFlags MoveFlag
+	for _, flag := range flags {
+		moveFlags |= flag
+	}
+	detector := newMoveDetector(r.Diffs, mode, moveFlags)
+	detector.run()
+	if mode == DimmedZebraMoves {
+		detector.dim()
+	}
+	detector.record()
+}
+
+type moveDetector struct {
+	diffs     []LineDiff
+	mode      MoveMode
+	flags     MoveFlag
+	keys      []string
+	indents   []int
+	next      []int
+	adds      map[string][]int
+	dels      map[string][]int
+	moved     []bool
+	alternate []bool
+	dimmed    []bool
+	starts    []bool
+	pairs     []int
+}
+
+// moveCandidate represents a potential source of a moved block by the
+// index of the currently matching line and the indentation delta.
+type moveCandidate struct {
+	index int
+	delta int
+}
+
+func newMoveDetector(diffs []LineDiff, mode MoveMode, flags MoveFlag) *moveDetector {
+	d := &moveDetector{
+		diffs:     diffs,
+		mode:      mode,
+		flags:     flags,
+		keys:      make([]string, len(diffs)),
+		indents:   make([]int, len(diffs)),
+		next:      make([]int, len(diffs)),
+		adds:      make(map[string][]int),
+		dels:      make(map[string][]int),
+		moved:     make([]bool, len(diffs)),
+		alternate: make([]bool, len(diffs)),
+		dimmed:    make([]bool, len(diffs)),
+		starts:    make([]bool, len(diffs)),
+		pairs:     make([]int, len(diffs)),
+	}
+	for index, diff := range diffs {
+		d.next[index] = -1
+		switch diff.Op {
+		case AddOp:
+			d.keys[index] = d.key(diff.Line)
+			d.adds[d.keys[index]] = append(d.adds[d.keys[index]], index)
+		case DelOp:
+			d.keys[index] = d.key(diff.Line)
+			d.dels[d.keys[index]] = append(d.dels[d.keys[index]], index)
+		default:
+			continue
+		}
+		d.indents[index] = lineIndent(diff.Line)
+		// blocks continue with the directly following line only
+		if index > 0 && diffs[index-1].Op == diff.Op {
+			d.next[index-1] = index
+		}
+	}
+	return d
+}
+
+func (d *moveDetector) key(line string) string {
+	key := strings.TrimSuffix(line, "\n")
+	switch {
+	case d.flags&MoveIgnoreAllSpaceFlag != 0:
+		key = removeSpace(key)
+	case d.flags&MoveIgnoreSpaceChangeFlag != 0:
+		key = collapseSpace(key)
+	case d.flags&MoveIgnoreSpaceAtEOLFlag != 0:
+		key = strings.TrimRightFunc(key, unicode.IsSpace)
+	}
+	if d.flags&MoveAllowIndentationChangeFlag != 0 {
+		key = strings.TrimLeftFunc(key, unicode.IsSpace)
+	}
+	return key
+}
+
+// run marks the moved lines (following git's mark_color_as_moved).
+func (d *moveDetector) run() {
+	candidates := make([]moveCandidate, 0)
+	blockOp := EqlOp
+	blockLength := 0
+	flipped := false
+	for n := 0; n < len(d.diffs); n++ {
+		op := d.diffs[n].Op
+		var matches []int
+		switch op {
+		case AddOp:
+			matches = d.dels[d.keys[n]]
+		case DelOp:
+			matches = d.adds[d.keys[n]]
+		default:
+			flipped = false
+		}
+		if len(candidates) > 0 && (matches == nil || op != blockOp) {
+			if !d.adjustBlock(n, blockLength) && blockLength > 1 {
+				// retry with the second line of the rejected block
+				matches = nil
+				n -= blockLength
+			}
+			candidates = candidates[:0]
+			blockLength = 0
+			flipped = false
+		}
+		if matches == nil {
+			blockOp = EqlOp
+			continue
+		}
+		if d.mode == PlainMoves {
+			d.moved[n] = true
+			d.pairs[n] = matches[0]
+			continue
+		}
+		if len(candidates) > 0 {
+			candidates = d.advance(candidates, n)
+		}
+		if len(candidates) == 0 {
+			contiguous := d.adjustBlock(n, blockLength)
+			if !contiguous && blockLength > 1 {
+				// retry with the second line of the rejected block
+				n -= blockLength
+			} else {
+				candidates = d.fill(candidates, matches, n)
+			}
+			if contiguous && len(candidates) > 0 && blockOp == op {
+				flipped = !flipped
+			} else {
+				flipped = false
+			}
+			if len(candidates) > 0 {
+				blockOp = op
+			} else {
+				blockOp = EqlOp
+			}
+			blockLength = 0
+		}
+		if len(candidates) > 0 {
+			d.moved[n] = true
+			d.alternate[n] = flipped && d.mode != BlockMoves
+			d.starts[n] = blockLength == 0
+			d.pairs[n] = candidates[0].index
+			blockLength++
+		}
+	}
+	d.adjustBlock(len(d.diffs), blockLength)
+}
+
+// fill collects the potential sources of a block starting at line n.
+func (d *moveDetector) fill(candidates []moveCandidate, matches []int, n int) []moveCandidate {
+	for _, match := range matches {
+		delta := 0
+		if d.flags&MoveAllowIndentationChangeFlag != 0 {
+			if d.indents[n] == noIndent || d.indents[match] == noIndent {
+				continue
+			}
+			delta = d.indents[n] - d.indents[match]
+		}
+		candidates = append(candidates, moveCandidate{index: match, delta: delta})
+	}
+	return candidates
+}
+
+// advance continues the potential sources of the current block with line n
+// and drops the ones not matching.
+func (d *moveDetector) advance(candidates []moveCandidate, n int) []moveCandidate {
+	advanced := candidates[:0]
+	for _, candidate := range candidates {
+		next := d.next[candidate.index]
+		if next < 0 || d.keys[next] != d.keys[n] {
+			continue
+		}
+		if d.flags&MoveAllowIndentationChangeFlag != 0 && (d.indents[n] != noIndent || d.indents[next] != noIndent) && d.indents[n]-d.indents[next] != candidate.delta {
+			continue
+		}
+		advanced = append(advanced, moveCandidate{index: next, delta: candidate.delta})
+	}
+	return advanced
+}
+
+// adjustBlock checks whether the block of the given length ending before
+// line n contains enough alphanumeric characters. If not, the block's lines
+// are unmarked.
+func (d *moveDetector) adjustBlock(n int, length int) bool {
+	if d.mode == PlainMoves {
+		return length > 0
+	}
+	count := 0
+	for _, diff := range d.diffs[n-length : n] {
+		for _, c := range []byte(diff.Line) {
+			if ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') {
+				count++
+			}
+		}
+		if count >= moveMinAlnumCount {
+			return true
+		}
+	}
+	for index := n - length; index < n; index++ {
+		d.moved[index] = false
+	}
+	return false
+}
+
+// dim marks the moved lines not being at the boundary of a block
+// (following git's dim_moved_lines).
+func (d *moveDetector) dim() {
+	changed := func(index int) bool {
+		return index >= 0 && index < len(d.diffs) && d.diffs[index].Op != EqlOp
+	}
+	sameBlock := func(index int, n int) bool {
+		return d.moved[index] == d.moved[n] && d.alternate[index] == d.alternate[n]
+	}
+	boundary := func(index int, n int) bool {
+		return d.moved[index] && d.alternate[index] != d.alternate[n]
+	}
+	for n := range d.diffs {
+		if d.diffs[n].Op == EqlOp || !d.moved[n] {
+			continue
+		}
+		prev := changed(n - 1)
+		next := changed(n + 1)
+		switch {
+		case prev && next && sameBlock(n-1, n) && sameBlock(n+1, n):
+			d.dimmed[n] = true
+		case prev && boundary(n-1, n):
+		case next && boundary(n+1, n):
+		default:
+			d.dimmed[n] = true
+		}
+	}
+}
+
+// record stores the detected moves in the diff entries.
+func (d *moveDetector) record() {
+	block := 0
+	for n := range d.diffs {
+		if !d.moved[n] {
+			continue
+		}
+		if d.starts[n] || n == 0 || !d.moved[n-1] || d.diffs[n-1].Op != d.diffs[n].Op {
+			block++
+		}
+		pair := d.diffs[d.pairs[n]]
+		number := pair.RightNumber
+		if pair.Op == DelOp {
+			number = pair.LeftNumber
+		}
+		d.diffs[n].Move = LineMove{
+			Block:     block,
+			Number:    number,
+			Alternate: d.alternate[n],
+			Dimmed:    d.dimmed[n],
+		}
+	}
+}
@@ -0,0 +1,155 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+// The expected outputs in testdata/move have been generated using:
+//
+//	git diff --no-index --color=always --color-moved=<mode> l.txt r.txt > <mode>.diff
+//	git diff --no-index --color=always --color-moved=<mode> --color-moved-ws=<ws> l.txt r.txt > <mode>_<ws>.diff
+//
+// The diff results are taken from git's output and the move markings are
+// derived from git's default move colors.
+func TestDetectMoves(t *testing.T) {
+	modes := map[string]diff.MoveMode{
+		"plain":        diff.PlainMoves,
+		"blocks":       diff.BlockMoves,
+		"zebra":        diff.ZebraMoves,
+		"dimmed-zebra": diff.DimmedZebraMoves,
+	}
+	flags := map[string]diff.MoveFlag{
+		"ignore-space-at-eol":      diff.MoveIgnoreSpaceAtEOLFlag,
+		"ignore-space-change":      diff.MoveIgnoreSpaceChangeFlag,
+		"ignore-all-space":         diff.MoveIgnoreAllSpaceFlag,
+		"allow-indentation-change": diff.MoveAllowIndentationChangeFlag,
+	}
+	expectedFiles, err := filepath.Glob("testdata/move/*.diff")
+	require.NoError(t, err)
+	require.NotEmpty(t, expectedFiles)
+	for _, expectedFile := range expectedFiles {
+		name := strings.TrimSuffix(filepath.Base(expectedFile), ".diff")
+		t.Run(name, func(t *testing.T) {
+			expected, err := os.ReadFile(expectedFile)
+			require.NoError(t, err)
+			result, markings := testParseColorMoved(t, string(expected))
+			mode, ws, _ := strings.Cut(name, "_")
+			moveFlags := make([]diff.MoveFlag, 0)
+			if ws != "" {
+				moveFlags = append(moveFlags, flags[ws])
+			}
+			result.DetectMoves(modes[mode], moveFlags...)
+			require.Equal(t, markings, testMoveMarkings(result))
+		})
+	}
+}
+
+func TestDetectMovesPairing(t *testing.T) {
+	left := []string{"moved line number one\n", "moved line number two\n", "a\n", "b\n"}
+	right := []string{"a\n", "b\n", "moved line number one\n", "moved line number two\n"}
+	result := diff.DiffLines(left, right)
+	result.DetectMoves(diff.ZebraMoves)
+	require.Equal(t, []diff.LineDiff{
+		{Op: diff.DelOp, Line: left[0], LeftNumber: 1, Move: diff.LineMove{Block: 1, Number: 3}},
+		{Op: diff.DelOp, Line: left[1], LeftNumber: 2, Move: diff.LineMove{Block: 1, Number: 4}},
+		{Op: diff.EqlOp, Line: left[2], LeftNumber: 3, RightNumber: 1},
+		{Op: diff.EqlOp, Line: left[3], LeftNumber: 4, RightNumber: 2},
+		{Op: diff.AddOp, Line: right[2], RightNumber: 3, Move: diff.LineMove{Block: 2, Number: 1}},
+		{Op: diff.AddOp, Line: right[3], RightNumber: 4, Move: diff.LineMove{Block: 2, Number: 2}},
+	}, result.Diffs)
+	inverted := result.Invert()
+	require.Equal(t, diff.LineDiff{Op: diff.AddOp, Line: left[0], RightNumber: 1, Move: diff.LineMove{Block: 1, Number: 3}}, inverted.Diffs[0])
+	require.Equal(t, diff.LineDiff{Op: diff.DelOp, Line: right[2], LeftNumber: 3, Move: diff.LineMove{Block: 2, Number: 1}}, inverted.Diffs[4])
+	result.DetectMoves(diff.NoMoves)
+	for _, line := range result.Diffs {
+		require.False(t, line.Moved())
+	}
+}
+
+func TestDetectMovesPrint(t *testing.T) {
+	left := []string{"moved line number one\n", "a\n", "deleted\n", "b\n"}
+	right := []string{"a\n", "b\n", "moved line number one\n"}
+	result := diff.DiffLines(left, right)
+	result.DetectMoves(diff.PlainMoves)
+	colors := &diff.Colors{Eql: "=", Add: "+", Del: "-", AddMoved: "M+", DelMoved: "M-", Rst: "."}
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithColors(colors)).Print(result)
+	require.Equal(t, "M-> moved line number one\n.== a\n.-> deleted\n.== b\n.M+< moved line number one\n.", output.String())
+	output.Reset()
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithColors(colors), diff.WithUnifiedFormatter(0)).Print(result)
+	require.Equal(t, "@@ -1,1 +0,0 @@.\nM--moved line number one\n.@@ -3,1 +1,0 @@.\n--deleted\n.@@ -4,0 +3,1 @@.\nM++moved line number one\n.", testStripHeader(output.String()))
+}
+
+var testAnsiPattern = regexp.MustCompile("\x1b\\[([0-9;]*)m")
+
+// testParseColorMoved parses git's colored output into a diff result and
+// the move markings of the changed lines.
+func testParseColorMoved(t *testing.T, output string) (*diff.Result, []string) {
+	markings := make([]string, 0)
+	for _, line := range strings.SplitAfter(output, "\n") {
+		text := testAnsiPattern.ReplaceAllString(line, "")
+		if (!strings.HasPrefix(text, "+") && !strings.HasPrefix(text, "-")) || strings.HasPrefix(text, "+++") || strings.HasPrefix(text, "---") {
+			continue
+		}
+		switch testAnsiPattern.FindStringSubmatch(line)[1] {
+		case "1;35", "1;36":
+			markings = append(markings, text[:1]+"moved")
+		case "1;34", "1;33":
+			markings = append(markings, text[:1]+"alternate")
+		case "2":
+			markings = append(markings, text[:1]+"dimmed")
+		case "2;3":
+			markings = append(markings, text[:1]+"alternate dimmed")
+		default:
+			markings = append(markings, text[:1])
+		}
+	}
+	patches, err := diff.ParseUnified(strings.NewReader(testAnsiPattern.ReplaceAllString(output, "")))
+	require.NoError(t, err)
+	require.Len(t, patches, 1)
+	result := &diff.Result{}
+	for _, hunk := range patches[0].Hunks {
+		result.Diffs = append(result.Diffs, hunk.Diffs...)
+	}
+	return result, markings
+}
+
+func testMoveMarkings(result *diff.Result) []string {
+	markings := make([]string, 0)
+	for _, line := range result.Diffs {
+		if line.Op == diff.EqlOp {
+			continue
+		}
+		marking := "-"
+		if line.Op == diff.AddOp {
+			marking = "+"
+		}
+		if line.Moved() {
+			switch {
+			case line.Move.Alternate && line.Move.Dimmed:
+				marking += "alternate dimmed"
+			case line.Move.Alternate:
+				marking += "alternate"
+			case line.Move.Dimmed:
+				marking += "dimmed"
+			default:
+				marking += "moved"
+			}
+		}
+		markings = append(markings, marking)
+	}
+	return markings
+}
@@ -0,0 +1,268 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"slices"
+)
+
+// runMyers runs the Myers algorithm on the given interned lines.
+func runMyers(s *script, a []int, b []int) {
+	l := len(a)
+	r := len(b)
+	switch {
+	case l == 0:
+		s.add(r)
+	case r == 0:
+		s.delete(l)
+	case l+r > s.linearThreshold:
+		runMyersLinear(s, a, b)
+	default:
+		runMyersFull(s, a, b)
+	}
+}
+
+func runMyersFull(s *script, a []int, b []int) {
+	l := len(a)
+	r := len(b)
+	max := l + r
+	v := make([]int, 2*max+1)
+	trace := make([][]int, 0, max)
+	for d := 0; d <= max; d++ {
+		if s.interrupted() {
+			return
+		}
+		if s.maxCost > 0 && d > s.maxCost {
+			break
+		}
+		dv := make([]int, len(v))
+		copy(dv, v)
+		trace = append(trace, dv)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[max+k-1] < v[max+k+1]) {
+				x = v[max+k+1]
+			} else {
+				x = v[max+k-1] + 1
+			}
+			y := x - k
+			for x < l && y < r && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[max+k] = x
+			if x >= l && y >= r {
+				runMyersFullBacktrack(s, trace, l, r, max)
+				return
+			}
+		}
+	}
+	runMyersFullHeuristic(s, trace, v, l, r, max)
+}
+
+// runMyersFullHeuristic completes the diff in case the edit cost limit has been
+// exceeded. The path to the furthest reaching point is kept and the remaining
+// lines are considered as deleted and added.
+func runMyersFullHeuristic(s *script, trace [][]int, v []int, l int, r int, max int) {
+	s.heuristic = true
+	d := len(trace) - 1
+	bestX := 0
+	bestY := 0
+	for k := -d; k <= d; k += 2 {
+		x := v[max+k]
+		y := x - k
+		if x <= l && y >= 0 && y <= r && x+y > bestX+bestY {
+			bestX = x
+			bestY = y
+		}
+	}
+	if bestX+bestY > 0 {
+		runMyersFullBacktrack(s, trace, bestX, bestY, max)
+	}
+	s.delete(l - bestX)
+	s.add(r - bestY)
+}
+
+func runMyersFullBacktrack(s *script, trace [][]int, x int, y int, max int) {
+	ops := make([]Op, 0, x+y)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[max+k-1] < v[max+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[max+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, EqlOp)
+		}
+		if d > 0 {
+			if prevX < x {
+				x--
+				ops = append(ops, DelOp)
+			} else {
+				y--
+				ops = append(ops, AddOp)
+			}
+		}
+	}
+	slices.Reverse(ops)
+	s.ops = append(s.ops, ops...)
+}
+
+func runMyersLinear(s *script, a []int, b []int) {
+	size := 2*((len(a)+len(b)+1)/2) + 2
+	vf := make([]int, size)
+	vb := make([]int, size)
+	runMyersLinearRange(s, a, b, vf, vb)
+}
+
+func runMyersLinearRange(s *script, a []int, b []int, vf []int, vb []int) {
+	if s.interrupted() {
+		return
+	}
+	// strip common prefix
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	s.keep(prefix)
+	a = a[prefix:]
+	b = b[prefix:]
+	// strip common suffix (and emit it after the remaining range)
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-suffix-1] == b[len(b)-suffix-1] {
+		suffix++
+	}
+	a = a[:len(a)-suffix]
+	b = b[:len(b)-suffix]
+	switch {
+	case len(a) == 0:
+		s.add(len(b))
+	case len(b) == 0:
+		s.delete(len(a))
+	default:
+		x, y, ok := runMyersLinearMiddle(s, a, b, vf, vb)
+		if ok {
+			runMyersLinearRange(s, a[:x], b[:y], vf, vb)
+			runMyersLinearRange(s, a[x:], b[y:], vf, vb)
+		} else {
+			s.delete(len(a))
+			s.add(len(b))
+		}
+	}
+	s.keep(suffix)
+}
+
+// runMyersLinearMiddle searches the middle snake of the given range by running the
+// Myers algorithm forward and backward simultaneously until both paths overlap.
+// The returned position splits the range in two independent sub ranges.
+//
+// If the edit cost limit is exceeded, the furthest reaching forward point is
+// used to split the range instead.
+func runMyersLinearMiddle(s *script, a []int, b []int, vf []int, vb []int) (int, int, bool) {
+	n := len(a)
+	m := len(b)
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	size := 2*maxD + 2
+	for i := range size {
+		vf[i] = -1
+		vb[i] = -1
+	}
+	vf[offset+1] = 0
+	vb[offset+1] = 0
+	delta := n - m
+	front := delta&1 != 0
+	kfStart, kfEnd, kbStart, kbEnd := 0, 0, 0, 0
+	for d := 0; d < maxD; d++ {
+		if s.interrupted() {
+			return 0, 0, false
+		}
+		if s.maxCost > 0 && d > s.maxCost {
+			return runMyersLinearHeuristic(s, n, m, vf, d-1, offset)
+		}
+		// forward path
+		for k := -d + kfStart; k <= d-kfEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && vf[offset+k-1] < vf[offset+k+1]) {
+				x = vf[offset+k+1]
+			} else {
+				x = vf[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			vf[offset+k] = x
+			if x > n {
+				kfEnd += 2
+			} else if y > m {
+				kfStart += 2
+			} else if front {
+				kb := offset + delta - k
+				if kb >= 0 && kb < size && vb[kb] != -1 && x >= n-vb[kb] {
+					return x, y, true
+				}
+			}
+		}
+		// backward path
+		for k := -d + kbStart; k <= d-kbEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && vb[offset+k-1] < vb[offset+k+1]) {
+				x = vb[offset+k+1]
+			} else {
+				x = vb[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			vb[offset+k] = x
+			if x > n {
+				kbEnd += 2
+			} else if y > m {
+				kbStart += 2
+			} else if !front {
+				kf := offset + delta - k
+				if kf >= 0 && kf < size && vf[kf] != -1 {
+					xf := vf[kf]
+					yf := offset + xf - kf
+					if xf >= n-x {
+						return xf, yf, true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func runMyersLinearHeuristic(s *script, n int, m int, vf []int, d int, offset int) (int, int, bool) {
+	s.heuristic = true
+	bestX := 0
+	bestY := 0
+	for k := -d; k <= d; k += 2 {
+		x := vf[offset+k]
+		y := x - k
+		if x >= 0 && x <= n && y >= 0 && y <= m && x+y > bestX+bestY {
+			bestX = x
+			bestY = y
+		}
+	}
+	if bestX+bestY == 0 || (bestX == n && bestY == m) {
+		return 0, 0, false
+	}
+	return bestX, bestY, true
+}
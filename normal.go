@@ -0,0 +1,77 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"fmt"
+)
+
+// WithNormalFormatter wraps WithFormatter to format the ouput in normal diff format
+// (like diff without any format option).
+func WithNormalFormatter() PrinterOption {
+	return PrinterOptionFunc(func(p *Printer) {
+		p.formatter = &normalFormatter{}
+	})
+}
+
+type normalFormatter struct{}
+
+func (f *normalFormatter) Format(p *Printer, r *Result) {
+	colors := p.Colors()
+	for hunk := range r.Hunks(0) {
+		fmt.Fprintf(p, "%s%s%c%s%s\n", colors.Lbl, formatLineRange(hunk.LeftStart, hunk.LeftLength), scriptCommand(hunk), formatLineRange(hunk.RightStart, hunk.RightLength), colors.Rst)
+		for _, diff := range hunk.Diffs {
+			if diff.Op == DelOp {
+				f.formatDiff(p, diff)
+			}
+		}
+		if hunk.LeftLength > 0 && hunk.RightLength > 0 {
+			fmt.Fprintf(p, "%s---%s\n", colors.Lbl, colors.Rst)
+		}
+		for _, diff := range hunk.Diffs {
+			if diff.Op == AddOp {
+				f.formatDiff(p, diff)
+			}
+		}
+	}
+}
+
+func (f *normalFormatter) formatDiff(p *Printer, diff LineDiff) {
+	var op string
+	set, rst := p.OpColor(diff.Op)
+	switch diff.Op {
+	case AddOp:
+		op = ">"
+	case DelOp:
+		op = "<"
+	}
+	fmt.Fprintf(p, "%s%s %s%s", set, op, diff.Line, rst)
+	if lineEnd(diff.Line) != "" {
+		fmt.Fprint(p, "\n"+noNewlineMarker)
+	}
+}
+
+// scriptCommand determines the command (add, change or delete)
+// describing a hunk without context lines.
+func scriptCommand(hunk Hunk) rune {
+	switch {
+	case hunk.LeftLength == 0:
+		return 'a'
+	case hunk.RightLength == 0:
+		return 'd'
+	}
+	return 'c'
+}
+
+// formatLineRange formats a line range as used by context and normal diffs
+// as well as ed scripts.
+func formatLineRange(start int, length int) string {
+	if length <= 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, start+length-1)
+}
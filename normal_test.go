@@ -0,0 +1,38 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestNormal(t *testing.T) {
+	testScriptFormatter(t, "testdata/script/", "normal.diff", diff.WithNormalFormatter())
+	testScriptFormatter(t, "testdata/script/dot_", "normal.diff", diff.WithNormalFormatter())
+}
+
+func TestNormalAnsi(t *testing.T) {
+	result := diff.DiffLines([]string{"a\n"}, []string{"b\n"})
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithNormalFormatter()).Print(result)
+	require.Equal(t, "\x1b[96m1c1\x1b[0m\n\x1b[31m< a\n\x1b[0m\x1b[96m---\x1b[0m\n\x1b[32m> b\n\x1b[0m", output.String())
+}
+
+func testScriptFormatter(t *testing.T, prefix string, expectedName string, opt diff.PrinterOption) {
+	result, err := diff.DiffFiles(prefix+"l.txt", prefix+"r.txt")
+	require.NoError(t, err)
+	expected, err := os.ReadFile(prefix + expectedName)
+	require.NoError(t, err)
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), opt).Print(result)
+	require.Equal(t, string(expected), output.String())
+}
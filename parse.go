@@ -0,0 +1,306 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseError indicates a malformed unified diff.
+//
+// If the input ends prematurely, the position at the end of the last input
+// line is reported. If a hunk's lines exceed the hunk's line counts, the position
+// of the exceeded line count in the hunk header is reported.
+type ParseError struct {
+	// Line contains the line number (1-based) of the malformed input line.
+	Line int
+	// Column contains the column (1-based) of the malformed input.
+	Column int
+	// Msg describes the error.
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("unified diff line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// ParseUnified parses the given unified diff into its file patches.
+//
+// Any input preceding or following the individual file patches (e.g. git's
+// extended header lines) is ignored. If the input is malformed, an error
+// of type [*ParseError] is returned.
+func ParseUnified(r io.Reader) ([]*Patch, error) {
+	parser := &unifiedParser{reader: bufio.NewReader(r)}
+	return parser.parse()
+}
+
+type unifiedParser struct {
+	reader     *bufio.Reader
+	line       string
+	lineNumber int
+	eof        bool
+}
+
+func (p *unifiedParser) next() error {
+	line, err := p.reader.ReadString('\n')
+	if err == io.EOF {
+		p.eof = line == ""
+	} else if err != nil {
+		return err
+	}
+	// keep the last line at end of input for error reporting
+	if !p.eof {
+		p.line = line
+		p.lineNumber++
+	}
+	return nil
+}
+
+func (p *unifiedParser) errorf(column int, format string, args ...any) error {
+	return p.errorAt(p.lineNumber, column, format, args...)
+}
+
+func (p *unifiedParser) errorAt(line int, column int, format string, args ...any) error {
+	return &ParseError{Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// eofErrorf reports an error at the end of the input.
+func (p *unifiedParser) eofErrorf(format string, args ...any) error {
+	return p.errorf(len(strings.TrimRight(p.line, "\r\n"))+1, format, args...)
+}
+
+func (p *unifiedParser) parse() ([]*Patch, error) {
+	patches := make([]*Patch, 0)
+	err := p.next()
+	for err == nil && !p.eof {
+		if !strings.HasPrefix(p.line, "--- ") {
+			err = p.next()
+			continue
+		}
+		var patch *Patch
+		patch, err = p.parsePatch()
+		if err == nil {
+			patches = append(patches, patch)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patches, nil
+}
+
+func (p *unifiedParser) parsePatch() (*Patch, error) {
+	patch := &Patch{}
+	patch.LeftName, patch.LeftInfo = p.parseFileHeader("--- ")
+	err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if p.eof {
+		return nil, p.eofErrorf("missing '+++ ' header line")
+	}
+	if !strings.HasPrefix(p.line, "+++ ") {
+		return nil, p.errorf(1, "missing '+++ ' header line")
+	}
+	patch.RightName, patch.RightInfo = p.parseFileHeader("+++ ")
+	err = p.next()
+	if err != nil {
+		return nil, err
+	}
+	if p.eof {
+		return nil, p.eofErrorf("missing hunk")
+	}
+	if !strings.HasPrefix(p.line, "@@ ") {
+		return nil, p.errorf(1, "missing hunk")
+	}
+	for !p.eof && strings.HasPrefix(p.line, "@@ ") {
+		hunk, err := p.parseHunk()
+		if err != nil {
+			return nil, err
+		}
+		patch.Hunks = append(patch.Hunks, hunk)
+	}
+	return patch, nil
+}
+
+func (p *unifiedParser) parseFileHeader(prefix string) (string, string) {
+	header := strings.TrimRight(strings.TrimPrefix(p.line, prefix), "\r\n")
+	name, info, _ := strings.Cut(header, "\t")
+	if strings.HasPrefix(name, "\"") {
+		unquoted, err := strconv.Unquote(name)
+		if err == nil {
+			name = unquoted
+		}
+	}
+	return name, info
+}
+
+func (p *unifiedParser) parseHunk() (Hunk, error) {
+	hunk, header, err := p.parseHunkRange()
+	if err != nil {
+		return hunk, err
+	}
+	leftNumber := max(hunk.LeftStart, 1)
+	rightNumber := max(hunk.RightStart, 1)
+	leftRemaining := hunk.LeftLength
+	rightRemaining := hunk.RightLength
+	for {
+		err = p.next()
+		if err != nil {
+			return hunk, err
+		}
+		if p.eof {
+			if leftRemaining > 0 || rightRemaining > 0 {
+				return hunk, p.eofErrorf("unexpected end of hunk (%d left and %d right lines missing)", leftRemaining, rightRemaining)
+			}
+			return hunk, nil
+		}
+		if strings.HasPrefix(p.line, "\\") {
+			if len(hunk.Diffs) == 0 {
+				return hunk, p.errorf(1, "unexpected no newline marker")
+			}
+			last := &hunk.Diffs[len(hunk.Diffs)-1]
+			last.Line = strings.TrimSuffix(last.Line, "\n")
+			continue
+		}
+		if leftRemaining == 0 && rightRemaining == 0 {
+			return hunk, nil
+		}
+		op := p.line[0]
+		line := p.line[1:]
+		if p.line == "\n" || p.line == "\r\n" {
+			// context line with stripped trailing space
+			op = ' '
+			line = p.line
+		}
+		switch op {
+		case ' ':
+			if leftRemaining == 0 {
+				return hunk, header.exceeded(p, header.leftColumn, hunk.LeftLength, "left")
+			}
+			if rightRemaining == 0 {
+				return hunk, header.exceeded(p, header.rightColumn, hunk.RightLength, "right")
+			}
+			hunk.Diffs = append(hunk.Diffs, LineDiff{Op: EqlOp, Line: line, LeftNumber: leftNumber, RightNumber: rightNumber})
+			leftNumber++
+			rightNumber++
+			leftRemaining--
+			rightRemaining--
+		case '-':
+			if leftRemaining == 0 {
+				return hunk, header.exceeded(p, header.leftColumn, hunk.LeftLength, "left")
+			}
+			hunk.Diffs = append(hunk.Diffs, LineDiff{Op: DelOp, Line: line, LeftNumber: leftNumber})
+			leftNumber++
+			leftRemaining--
+		case '+':
+			if rightRemaining == 0 {
+				return hunk, header.exceeded(p, header.rightColumn, hunk.RightLength, "right")
+			}
+			hunk.Diffs = append(hunk.Diffs, LineDiff{Op: AddOp, Line: line, RightNumber: rightNumber})
+			rightNumber++
+			rightRemaining--
+		default:
+			return hunk, p.errorf(1, "unexpected hunk line (%d left and %d right lines missing)", leftRemaining, rightRemaining)
+		}
+	}
+}
+
+// hunkHeader records the position of a hunk header's line counts.
+type hunkHeader struct {
+	line        int
+	leftColumn  int
+	rightColumn int
+}
+
+// exceeded reports a hunk line exceeding the line count at the given column.
+func (h hunkHeader) exceeded(p *unifiedParser, column int, count int, side string) error {
+	return p.errorAt(h.line, column, "%s line count %d exceeded by hunk line %d", side, count, p.lineNumber)
+}
+
+// parseHunkRange parses a hunk header line of the form
+// "@@ -l[,s] +l[,s] @@[ section]".
+func (p *unifiedParser) parseHunkRange() (Hunk, hunkHeader, error) {
+	hunk := Hunk{}
+	header := hunkHeader{line: p.lineNumber}
+	scanner := &rangeScanner{line: p.line, pos: len("@@ ")}
+	if !scanner.expect("-") {
+		return hunk, header, p.errorf(scanner.column(), "expected '-'")
+	}
+	var err error
+	hunk.LeftStart, hunk.LeftLength, header.leftColumn, err = scanner.scanRange()
+	if err != nil {
+		return hunk, header, p.errorf(scanner.column(), "%s", err)
+	}
+	if !scanner.expect(" +") {
+		return hunk, header, p.errorf(scanner.column(), "expected ' +'")
+	}
+	hunk.RightStart, hunk.RightLength, header.rightColumn, err = scanner.scanRange()
+	if err != nil {
+		return hunk, header, p.errorf(scanner.column(), "%s", err)
+	}
+	if !scanner.expect(" @@") {
+		return hunk, header, p.errorf(scanner.column(), "expected ' @@'")
+	}
+	return hunk, header, nil
+}
+
+type rangeScanner struct {
+	line string
+	pos  int
+}
+
+func (s *rangeScanner) column() int {
+	return s.pos + 1
+}
+
+func (s *rangeScanner) expect(token string) bool {
+	if !strings.HasPrefix(s.line[s.pos:], token) {
+		return false
+	}
+	s.pos += len(token)
+	return true
+}
+
+// scanRange scans a range "l[,s]" and returns its start and length as well as
+// the column of the length (or of the start, if the length is implied).
+func (s *rangeScanner) scanRange() (int, int, int, error) {
+	lengthColumn := s.column()
+	start, err := s.scanNumber()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	length := 1
+	if s.expect(",") {
+		lengthColumn = s.column()
+		length, err = s.scanNumber()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+	}
+	return start, length, lengthColumn, nil
+}
+
+func (s *rangeScanner) scanNumber() (int, error) {
+	end := s.pos
+	for end < len(s.line) && '0' <= s.line[end] && s.line[end] <= '9' {
+		end++
+	}
+	if end == s.pos {
+		return 0, fmt.Errorf("expected number")
+	}
+	number, err := strconv.Atoi(s.line[s.pos:end])
+	if err != nil {
+		return 0, err
+	}
+	s.pos = end
+	return number, nil
+}
@@ -0,0 +1,124 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestParseUnifiedRoundTrip(t *testing.T) {
+	inputs := [][2]string{
+		{leftFileName, rightFileName},
+		{emptyFileName, rightFileName},
+		{leftFileName, emptyFileName},
+		{"testdata/histogram/code_l.txt", "testdata/histogram/code_r.txt"},
+	}
+	for _, input := range inputs {
+		result, err := diff.DiffFiles(input[0], input[1])
+		require.NoError(t, err)
+		for _, context := range []int{0, 1, diff.DefaultUnifiedContext} {
+			output := &strings.Builder{}
+			diff.NewPrinter(output, diff.WithAnsi(false), diff.WithUnifiedFormatter(context)).Print(result)
+			patches, err := diff.ParseUnified(strings.NewReader(output.String()))
+			require.NoError(t, err)
+			require.Len(t, patches, 1)
+			require.Equal(t, input[0], patches[0].LeftName)
+			require.Equal(t, input[1], patches[0].RightName)
+			require.Equal(t, slices.Collect(result.Hunks(context)), patches[0].Hunks)
+		}
+	}
+}
+
+const testMultiFilePatch = `diff --git a/one.txt b/one.txt
+index 3be9c81..2e7a9f4 100644
+--- a/one.txt
++++ b/one.txt
+@@ -1,2 +1,2 @@ section
+ a
+-b
+\ No newline at end of file
++b
+diff --git a/two.txt b/two.txt
+new file mode 100644
+--- /dev/null
++++ b/two.txt
+@@ -0,0 +1 @@
++new
+`
+
+func TestParseUnifiedMultiFile(t *testing.T) {
+	patches, err := diff.ParseUnified(strings.NewReader(testMultiFilePatch))
+	require.NoError(t, err)
+	require.Equal(t, []*diff.Patch{
+		{
+			LeftName:  "a/one.txt",
+			RightName: "b/one.txt",
+			Hunks: []diff.Hunk{{
+				LeftStart:   1,
+				LeftLength:  2,
+				RightStart:  1,
+				RightLength: 2,
+				Diffs: []diff.LineDiff{
+					{Op: diff.EqlOp, Line: "a\n", LeftNumber: 1, RightNumber: 1},
+					{Op: diff.DelOp, Line: "b", LeftNumber: 2},
+					{Op: diff.AddOp, Line: "b\n", RightNumber: 2},
+				},
+			}},
+		},
+		{
+			LeftName:  "/dev/null",
+			RightName: "b/two.txt",
+			Hunks: []diff.Hunk{{
+				LeftStart:   0,
+				LeftLength:  0,
+				RightStart:  1,
+				RightLength: 1,
+				Diffs: []diff.LineDiff{
+					{Op: diff.AddOp, Line: "new\n", RightNumber: 1},
+				},
+			}},
+		},
+	}, patches)
+}
+
+func TestParseUnifiedErrors(t *testing.T) {
+	inputs := []struct {
+		patch  string
+		line   int
+		column int
+	}{
+		{"--- a\nxxx b\n", 2, 1},
+		{"--- a\n", 1, 6},
+		{"--- a\n+++ b\n", 2, 6},
+		// truncated hunk
+		{"--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n", 4, 3},
+		{"--- a\n+++ b\n@@ -1,3 +1,3 @@\n a\n b", 5, 3},
+		{"--- a\n+++ b\n@@ -1,x +1,2 @@\n", 3, 7},
+		{"--- a\n+++ b\n@@ -1,1 1,2 @@\n", 3, 8},
+		{"--- a\n+++ b\n@@ -1,1 +1,1 @\n", 3, 13},
+		{"--- a\n+++ b\n@@ -1,1 +1,1 @@\n*a\n", 4, 1},
+		{"--- a\n+++ b\n@@ -1,1 +1,1 @@\n\\ No newline at end of file\n", 4, 1},
+		// bad count
+		{"--- a\n+++ b\n@@ -1,1 +1,1 @@\n-a\n-b\n", 3, 7},
+		{"--- a\n+++ b\n@@ -1,2 +1,1 @@\n a\n+b\n", 3, 12},
+		{"--- a\n+++ b\n@@ -1 +1,2 @@\n a\n a\n", 3, 5},
+	}
+	for _, input := range inputs {
+		_, err := diff.ParseUnified(strings.NewReader(input.patch))
+		parseErr := &diff.ParseError{}
+		require.ErrorAs(t, err, &parseErr, input.patch)
+		require.Equal(t, input.line, parseErr.Line, input.patch)
+		require.Equal(t, input.column, parseErr.Column, input.patch)
+	}
+	_, err := diff.ParseUnified(strings.NewReader("--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n+b\n+c\n"))
+	require.EqualError(t, err, "unified diff line 3, column 12: right line count 2 exceeded by hunk line 6")
+}
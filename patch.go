@@ -0,0 +1,322 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Patch represents the changes of a single file as described
+// by a unified diff.
+type Patch struct {
+	// LeftName contains the name of the left (original) file.
+	LeftName string
+	// LeftInfo contains the additional information (e.g. modification time)
+	// following the left file name in the patch header.
+	LeftInfo string
+	// RightName contains the name of the right (modified) file.
+	RightName string
+	// RightInfo contains the additional information (e.g. modification time)
+	// following the right file name in the patch header.
+	RightInfo string
+	// Hunks contains the hunks of this patch.
+	Hunks []Hunk
+}
+
+// Patch creates a Patch containing the hunks of the diff result
+// using the given context size.
+func (r *Result) Patch(context int) *Patch {
+	return &Patch{
+		LeftName:  r.LeftName,
+		RightName: r.RightName,
+		Hunks:     slices.Collect(r.Hunks(context)),
+	}
+}
+
+// DefaultFuzz defines the default fuzz factor (2) used for applying patches.
+const DefaultFuzz int = 2
+
+// HunkResult describes the outcome of applying a single hunk.
+type HunkResult struct {
+	// Index contains the index of the hunk within the applied patch.
+	Index int
+	// Hunk contains the applied hunk.
+	Hunk Hunk
+	// Applied indicates, whether the hunk has been applied.
+	Applied bool
+	// Offset contains the number of lines the hunk has been shifted
+	// with respect to the position recorded in the hunk.
+	Offset int
+	// Fuzz contains the number of leading and trailing context lines
+	// ignored while applying the hunk.
+	Fuzz int
+	// Reason describes why the hunk has been rejected.
+	Reason string
+}
+
+// ApplyResult contains the result of an Apply operation.
+type ApplyResult struct {
+	// Lines contains the patched lines.
+	Lines []string
+	// Hunks contains for all hunks of the applied patch the outcome.
+	Hunks []HunkResult
+}
+
+// Rejects returns the outcome of all rejected hunks.
+func (r *ApplyResult) Rejects() []HunkResult {
+	rejects := make([]HunkResult, 0)
+	for _, hunk := range r.Hunks {
+		if !hunk.Applied {
+			rejects = append(rejects, hunk)
+		}
+	}
+	return rejects
+}
+
+// ApplyError indicates, one or more hunks could not be applied.
+type ApplyError struct {
+	// Rejects contains the outcome of all rejected hunks.
+	Rejects []HunkResult
+}
+
+func (e *ApplyError) Error() string {
+	reasons := make([]string, 0, len(e.Rejects))
+	for _, reject := range e.Rejects {
+		reasons = append(reasons, fmt.Sprintf("hunk #%d (@@ -%d,%d +%d,%d @@): %s", reject.Index+1, reject.Hunk.LeftStart, reject.Hunk.LeftLength, reject.Hunk.RightStart, reject.Hunk.RightLength, reject.Reason))
+	}
+	return fmt.Sprintf("%d hunk(s) rejected: %s", len(e.Rejects), strings.Join(reasons, "; "))
+}
+
+// Applier type supports configurable applying of patches.
+type Applier struct {
+	strict    bool
+	reverse   bool
+	fuzz      int
+	maxOffset int
+}
+
+// Apply applies the given patch to the given reader's contents.
+//
+// See [Applier.ApplyLines] for details.
+func (a *Applier) Apply(r io.Reader, patch *Patch) (*ApplyResult, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+	return a.ApplyLines(lines, patch)
+}
+
+// ApplyLines applies the given patch to the given lines.
+//
+// The hunks are applied in order. A hunk is applied at the position recorded in
+// the hunk, or if its lines do not match there, at the nearest position within
+// the configured offset where they match. If the hunk still does not match, up
+// to fuzz leading and trailing context lines are ignored. Hunks not matching at
+// all are rejected.
+//
+// If one or more hunks are rejected, an error of type [*ApplyError] is
+// returned together with the result containing all applicable hunks (or no
+// result at all in strict mode).
+//
+// In reverse mode, the inverted patch is applied (see [Patch.Invert]).
+func (a *Applier) ApplyLines(lines []string, patch *Patch) (*ApplyResult, error) {
+	if a.reverse {
+		patch = patch.Invert()
+	}
+	result := &ApplyResult{
+		Lines: make([]string, 0, len(lines)),
+		Hunks: make([]HunkResult, 0, len(patch.Hunks)),
+	}
+	position := 0
+	offset := 0
+	for index, hunk := range patch.Hunks {
+		hunkResult := HunkResult{Index: index, Hunk: hunk}
+		oldLines, newLines := hunkLines(hunk)
+		leading, trailing := hunkContext(hunk)
+		expected := hunk.LeftStart - 1
+		if hunk.LeftLength == 0 {
+			expected = hunk.LeftStart
+		}
+		// fuzz beyond the available context lines does not change the pattern
+		maxFuzz := min(a.fuzz, max(leading, trailing))
+		for fuzz := 0; fuzz <= maxFuzz; fuzz++ {
+			skipLeading := min(fuzz, leading)
+			skipTrailing := min(fuzz, trailing)
+			pattern := oldLines[skipLeading : len(oldLines)-skipTrailing]
+			match := a.search(lines, position, expected+offset+skipLeading, pattern)
+			if match < 0 {
+				continue
+			}
+			hunkResult.Applied = true
+			hunkResult.Fuzz = fuzz
+			hunkResult.Offset = match - skipLeading - expected
+			result.Lines = append(result.Lines, lines[position:match]...)
+			result.Lines = append(result.Lines, newLines[skipLeading:len(newLines)-skipTrailing]...)
+			position = match + len(pattern)
+			offset = hunkResult.Offset
+			break
+		}
+		if !hunkResult.Applied {
+			hunkResult.Reason = "hunk does not match"
+		}
+		result.Hunks = append(result.Hunks, hunkResult)
+	}
+	result.Lines = append(result.Lines, lines[position:]...)
+	rejects := result.Rejects()
+	if len(rejects) > 0 {
+		err := &ApplyError{Rejects: rejects}
+		if a.strict {
+			return nil, err
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// search searches the given pattern starting at the expected position and
+// continuing alternating backward and forward up to the maximum offset.
+// Lines before the given minimum position are not considered.
+func (a *Applier) search(lines []string, minimum int, expected int, pattern []string) int {
+	maximum := len(lines) - len(pattern)
+	for delta := 0; a.maxOffset < 0 || delta <= a.maxOffset; delta++ {
+		backward := expected - delta
+		forward := expected + delta
+		if backward < minimum && forward > maximum {
+			break
+		}
+		if backward >= minimum && backward <= maximum && slices.Equal(lines[backward:backward+len(pattern)], pattern) {
+			return backward
+		}
+		if delta > 0 && forward >= minimum && forward <= maximum && slices.Equal(lines[forward:forward+len(pattern)], pattern) {
+			return forward
+		}
+	}
+	return -1
+}
+
+// hunkLines determines the lines a hunk expects (old) and produces (new).
+func hunkLines(hunk Hunk) ([]string, []string) {
+	oldLines := make([]string, 0, hunk.LeftLength)
+	newLines := make([]string, 0, hunk.RightLength)
+	for _, diff := range hunk.Diffs {
+		switch diff.Op {
+		case EqlOp:
+			oldLines = append(oldLines, diff.Line)
+			newLines = append(newLines, rightLine(diff))
+		case AddOp:
+			newLines = append(newLines, diff.Line)
+		case DelOp:
+			oldLines = append(oldLines, diff.Line)
+		}
+	}
+	return oldLines, newLines
+}
+
+// hunkContext determines the number of leading and trailing context lines.
+func hunkContext(hunk Hunk) (int, int) {
+	leading := 0
+	for leading < len(hunk.Diffs) && hunk.Diffs[leading].Op == EqlOp {
+		leading++
+	}
+	trailing := 0
+	for trailing < len(hunk.Diffs)-leading && hunk.Diffs[len(hunk.Diffs)-trailing-1].Op == EqlOp {
+		trailing++
+	}
+	return leading, trailing
+}
+
+// ApplyOption interface is used to configure an Applier instance.
+type ApplyOption interface {
+	// Apply applies the options represented by this instance
+	// to the given Applier instance.
+	Apply(a *Applier)
+}
+
+// ApplyOptionFunc typed functions are used to configure an Applier instance.
+type ApplyOptionFunc func(*Applier)
+
+// Apply applies options to the given Applier instance.
+func (f ApplyOptionFunc) Apply(a *Applier) {
+	f(a)
+}
+
+// WithStrict enables strict mode.
+//
+// In strict mode, hunks must match exactly at their recorded position
+// (no offset and no fuzz) and the patch is only applied if all hunks match.
+func WithStrict() ApplyOption {
+	return ApplyOptionFunc(func(a *Applier) {
+		a.strict = true
+	})
+}
+
+// WithReverse enables reverse mode.
+//
+// In reverse mode, the patch is assumed to have been created with left
+// and right swapped. Applying it to the right content restores the left one.
+func WithReverse() ApplyOption {
+	return ApplyOptionFunc(func(a *Applier) {
+		a.reverse = true
+	})
+}
+
+// WithFuzz sets the maximum number of leading and trailing context lines
+// to ignore, if a hunk does not match otherwise.
+//
+// Per default [DefaultFuzz] is used. A negative fuzz resets the setting
+// to the default.
+func WithFuzz(fuzz int) ApplyOption {
+	checkedFuzz := fuzz
+	if checkedFuzz < 0 {
+		checkedFuzz = DefaultFuzz
+	}
+	return ApplyOptionFunc(func(a *Applier) {
+		a.fuzz = checkedFuzz
+	})
+}
+
+// WithMaxOffset sets the maximum number of lines a hunk may be shifted
+// with respect to its recorded position.
+//
+// Per default the whole input is searched. A negative offset resets the
+// setting to the default.
+func WithMaxOffset(offset int) ApplyOption {
+	return ApplyOptionFunc(func(a *Applier) {
+		a.maxOffset = max(offset, -1)
+	})
+}
+
+// NewApplier creates a new Applier instance using the given apply options.
+func NewApplier(opts ...ApplyOption) *Applier {
+	applier := &Applier{
+		fuzz:      DefaultFuzz,
+		maxOffset: -1,
+	}
+	for _, opt := range opts {
+		opt.Apply(applier)
+	}
+	if applier.strict {
+		applier.fuzz = 0
+		applier.maxOffset = 0
+	}
+	return applier
+}
+
+// Apply applies the given patch to the given reader's contents
+// using the given apply options.
+func Apply(r io.Reader, patch *Patch, opts ...ApplyOption) (*ApplyResult, error) {
+	return NewApplier(opts...).Apply(r, patch)
+}
+
+// ApplyLines applies the given patch to the given lines
+// using the given apply options.
+func ApplyLines(lines []string, patch *Patch, opts ...ApplyOption) (*ApplyResult, error) {
+	return NewApplier(opts...).ApplyLines(lines, patch)
+}
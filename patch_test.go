@@ -0,0 +1,105 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestApplyResult(t *testing.T) {
+	inputs := [][2]string{
+		{leftFileName, rightFileName},
+		{emptyFileName, rightFileName},
+		{leftFileName, emptyFileName},
+		{"testdata/histogram/code_l.txt", "testdata/histogram/code_r.txt"},
+		{"testdata/histogram/blocks_l.txt", "testdata/histogram/blocks_r.txt"},
+	}
+	for _, input := range inputs {
+		result, err := diff.DiffFiles(input[0], input[1])
+		require.NoError(t, err)
+		left := testReadLines(t, input[0])
+		right := testReadLines(t, input[1])
+		for _, context := range []int{0, 1, diff.DefaultUnifiedContext} {
+			applied, err := diff.ApplyLines(left, result.Patch(context), diff.WithStrict())
+			require.NoError(t, err)
+			require.Equal(t, right, applied.Lines)
+		}
+	}
+}
+
+func TestApplyParsed(t *testing.T) {
+	result, err := diff.DiffFiles(leftFileName, rightFileName)
+	require.NoError(t, err)
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithUnifiedFormatter(diff.DefaultUnifiedContext)).Print(result)
+	patches, err := diff.ParseUnified(strings.NewReader(output.String()))
+	require.NoError(t, err)
+	require.Len(t, patches, 1)
+	file, err := os.Open(leftFileName)
+	require.NoError(t, err)
+	defer file.Close()
+	applied, err := diff.Apply(file, patches[0])
+	require.NoError(t, err)
+	require.Equal(t, testReadLines(t, rightFileName), applied.Lines)
+}
+
+var testApplyLeft = []string{"a\n", "b\n", "c\n", "d\n", "e\n", "f\n", "g\n"}
+var testApplyRight = []string{"a\n", "b\n", "c\n", "D\n", "e\n", "f\n", "g\n"}
+
+func TestApplyOffset(t *testing.T) {
+	patch := diff.DiffLines(testApplyLeft, testApplyRight).Patch(2)
+	shifted := append([]string{"x\n", "y\n"}, testApplyLeft...)
+	applied, err := diff.ApplyLines(shifted, patch)
+	require.NoError(t, err)
+	require.Equal(t, append([]string{"x\n", "y\n"}, testApplyRight...), applied.Lines)
+	require.Len(t, applied.Hunks, 1)
+	require.True(t, applied.Hunks[0].Applied)
+	require.Equal(t, 2, applied.Hunks[0].Offset)
+	require.Equal(t, 0, applied.Hunks[0].Fuzz)
+
+	_, err = diff.ApplyLines(shifted, patch, diff.WithMaxOffset(1))
+	require.Error(t, err)
+}
+
+func TestApplyFuzz(t *testing.T) {
+	patch := diff.DiffLines(testApplyLeft, testApplyRight).Patch(2)
+	changed := []string{"a\n", "B\n", "c\n", "d\n", "e\n", "F\n", "g\n"}
+	applied, err := diff.ApplyLines(changed, patch)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a\n", "B\n", "c\n", "D\n", "e\n", "F\n", "g\n"}, applied.Lines)
+	require.Equal(t, 1, applied.Hunks[0].Fuzz)
+
+	_, err = diff.ApplyLines(changed, patch, diff.WithFuzz(0))
+	require.Error(t, err)
+}
+
+func TestApplyRejects(t *testing.T) {
+	left := []string{"a\n", "b\n", "c\n", "d\n", "e\n", "f\n", "g\n", "h\n", "i\n", "j\n"}
+	right := []string{"A\n", "b\n", "c\n", "d\n", "e\n", "f\n", "g\n", "h\n", "i\n", "J\n"}
+	patch := diff.DiffLines(left, right).Patch(1)
+	require.Len(t, patch.Hunks, 2)
+	changed := []string{"a\n", "b\n", "c\n", "d\n", "e\n", "f\n", "g\n", "h\n", "x\n", "y\n"}
+
+	applied, err := diff.ApplyLines(changed, patch, diff.WithFuzz(0))
+	var applyErr *diff.ApplyError
+	require.ErrorAs(t, err, &applyErr)
+	require.Len(t, applyErr.Rejects, 1)
+	require.Equal(t, 1, applyErr.Rejects[0].Index)
+	require.NotEmpty(t, applyErr.Rejects[0].Reason)
+	require.Equal(t, applyErr.Rejects, applied.Rejects())
+	require.True(t, applied.Hunks[0].Applied)
+	require.Equal(t, []string{"A\n", "b\n", "c\n", "d\n", "e\n", "f\n", "g\n", "h\n", "x\n", "y\n"}, applied.Lines)
+
+	applied, err = diff.ApplyLines(changed, patch, diff.WithStrict())
+	require.ErrorAs(t, err, &applyErr)
+	require.Nil(t, applied)
+}
@@ -0,0 +1,118 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"sort"
+)
+
+// runPatience runs the patience diff algorithm on the given interned lines.
+//
+// Lines occurring exactly once on both sides are used as anchors. The longest
+// sequence of anchors appearing in the same order on both sides splits the input
+// in independent ranges, which are diffed recursively. Ranges without any
+// anchor are diffed using the Myers algorithm.
+func runPatience(s *script, a []int, b []int) {
+	if s.interrupted() {
+		return
+	}
+	// strip common prefix
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	s.keep(prefix)
+	a = a[prefix:]
+	b = b[prefix:]
+	// strip common suffix (and emit it after the remaining range)
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-suffix-1] == b[len(b)-suffix-1] {
+		suffix++
+	}
+	a = a[:len(a)-suffix]
+	b = b[:len(b)-suffix]
+	anchors := patienceAnchors(a, b)
+	if len(anchors) == 0 {
+		runMyers(s, a, b)
+	} else {
+		x := 0
+		y := 0
+		for _, anchor := range anchors {
+			runPatience(s, a[x:anchor.x], b[y:anchor.y])
+			s.keep(1)
+			x = anchor.x + 1
+			y = anchor.y + 1
+		}
+		runPatience(s, a[x:], b[y:])
+	}
+	s.keep(suffix)
+}
+
+type patienceAnchor struct {
+	x int
+	y int
+}
+
+// patienceAnchors determines the longest sequence of unique lines
+// appearing in the same order on both sides.
+func patienceAnchors(a []int, b []int) []patienceAnchor {
+	type occurrence struct {
+		countA int
+		countB int
+		y      int
+	}
+	occurrences := make(map[int]*occurrence)
+	for _, id := range a {
+		o := occurrences[id]
+		if o == nil {
+			o = &occurrence{}
+			occurrences[id] = o
+		}
+		o.countA++
+	}
+	for y, id := range b {
+		o := occurrences[id]
+		if o != nil {
+			o.countB++
+			o.y = y
+		}
+	}
+	// unique matches in left order
+	candidates := make([]patienceAnchor, 0)
+	for x, id := range a {
+		o := occurrences[id]
+		if o.countA == 1 && o.countB == 1 {
+			candidates = append(candidates, patienceAnchor{x: x, y: o.y})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	// patience sorting to determine the longest increasing subsequence (by y)
+	piles := make([]int, 0)
+	backlinks := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		pile := sort.Search(len(piles), func(p int) bool {
+			return candidates[piles[p]].y > candidate.y
+		})
+		if pile > 0 {
+			backlinks[i] = piles[pile-1]
+		} else {
+			backlinks[i] = -1
+		}
+		if pile == len(piles) {
+			piles = append(piles, i)
+		} else {
+			piles[pile] = i
+		}
+	}
+	anchors := make([]patienceAnchor, len(piles))
+	for i, c := len(anchors)-1, piles[len(piles)-1]; i >= 0; i, c = i-1, backlinks[c] {
+		anchors[i] = candidates[c]
+	}
+	return anchors
+}
@@ -0,0 +1,47 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestPatience(t *testing.T) {
+	left := []string{
+		"foo()\n",
+		"}\n",
+		"}\n",
+	}
+	right := []string{
+		"}\n",
+		"foo()\n",
+	}
+	myersResult := diff.DiffLines(left, right)
+	require.Equal(t, []diff.LineDiff{
+		{Op: diff.DelOp, Line: "foo()\n", LeftNumber: 1},
+		{Op: diff.EqlOp, Line: "}\n", LeftNumber: 2, RightNumber: 1},
+		{Op: diff.DelOp, Line: "}\n", LeftNumber: 3},
+		{Op: diff.AddOp, Line: "foo()\n", RightNumber: 2},
+	}, myersResult.Diffs)
+	patienceResult := diff.DiffLines(left, right, diff.WithAlgorithm(diff.PatienceAlgorithm))
+	require.Equal(t, []diff.LineDiff{
+		{Op: diff.AddOp, Line: "}\n", RightNumber: 1},
+		{Op: diff.EqlOp, Line: "foo()\n", LeftNumber: 1, RightNumber: 2},
+		{Op: diff.DelOp, Line: "}\n", LeftNumber: 2},
+		{Op: diff.DelOp, Line: "}\n", LeftNumber: 3},
+	}, patienceResult.Diffs)
+}
+
+func TestPatienceFiles(t *testing.T) {
+	result, err := diff.DiffFiles(leftFileName, rightFileName, diff.WithAlgorithm(diff.PatienceAlgorithm))
+	require.NoError(t, err)
+	require.Len(t, result.Diffs, 39)
+	require.Equal(t, 13, testEditCount(result))
+}
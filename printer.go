@@ -10,16 +10,22 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 
 	"github.com/mattn/go-isatty"
 )
 
 // Printer type supports configurable formatting and printing of diff results.
 type Printer struct {
-	w         io.Writer
-	ansi      bool
-	colors    *Colors
-	formatter Formatter
+	w              io.Writer
+	ansi           bool
+	colors         *Colors
+	lineNumbers    bool
+	highlight      bool
+	width          int
+	terminalWidth  int
+	formatter      Formatter
+	mergeFormatter MergeFormatter
 }
 
 // Write as defined by [io.Writer]
@@ -45,6 +51,25 @@ func (p *Printer) Colors() *Colors {
 	return p.colors
 }
 
+// DefaultWidth defines the default output width (130 columns)
+// used if the output width cannot be determined otherwise.
+const DefaultWidth int = 130
+
+// Width returns the output width (in columns) configured for this Printer instance.
+//
+// If no width has been configured explicitly, the terminal width of the
+// [io.Writer] provided during creation is used. If the writer is not a terminal,
+// [DefaultWidth] is returned.
+func (p *Printer) Width() int {
+	if p.width > 0 {
+		return p.width
+	}
+	if p.terminalWidth > 0 {
+		return p.terminalWidth
+	}
+	return DefaultWidth
+}
+
 // OpColor returns the ansi color sequence configured for the given diff operation.
 //
 // Beside the color sequence, also the reset sequence is returned to reset coloring
@@ -63,24 +88,94 @@ func (p *Printer) OpColor(op Op) (string, string) {
 	return "", ""
 }
 
+// LineColor returns the ansi color sequence configured for the given line.
+//
+// Moved lines (see [Result.DetectMoves]) are colored using the move colors.
+// All other lines are colored like [Printer.OpColor] does.
+func (p *Printer) LineColor(diff LineDiff) (string, string) {
+	if diff.Moved() {
+		colors := p.Colors()
+		var set string
+		switch diff.Op {
+		case AddOp:
+			set = selectMoveColor(diff.Move, colors.AddMoved, colors.AddMovedAlt, colors.AddMovedDim, colors.AddMovedAltDim)
+		case DelOp:
+			set = selectMoveColor(diff.Move, colors.DelMoved, colors.DelMovedAlt, colors.DelMovedDim, colors.DelMovedAltDim)
+		}
+		if set != "" {
+			return set, colors.Rst
+		}
+	}
+	return p.OpColor(diff.Op)
+}
+
+func selectMoveColor(move LineMove, moved string, alt string, dim string, altDim string) string {
+	switch {
+	case move.Dimmed && move.Alternate:
+		return altDim
+	case move.Dimmed:
+		return dim
+	case move.Alternate:
+		return alt
+	}
+	return moved
+}
+
 // Print prints the given diff result according to the Printer's configuration.
 func (p *Printer) Print(r *Result) {
 	p.formatter.Format(p, r)
 }
 
+// PrintMerge prints the given merge result according to the Printer's configuration.
+func (p *Printer) PrintMerge(r *MergeResult) {
+	p.mergeFormatter.FormatMerge(p, r)
+}
+
 func (p *Printer) defaultPrint(r *Result) {
+	width := 0
+	if p.lineNumbers {
+		width = lineNumberWidth(r)
+	}
 	if p.ansi {
 		for _, diff := range r.Diffs {
-			set, rst := p.OpColor(diff.Op)
-			fmt.Fprintf(p.w, "%s%s %s%s", set, diff.Op, diff.Line, rst)
+			set, rst := p.LineColor(diff)
+			fmt.Fprintf(p.w, "%s%s%s %s%s%s", set, formatLineNumbers(diff, width), diff.Op, diff.Line, rst, lineEnd(diff.Line))
 		}
 	} else {
 		for _, diff := range r.Diffs {
-			fmt.Fprintf(p.w, "%s %s", diff.Op, diff.Line)
+			fmt.Fprintf(p.w, "%s%s %s%s", formatLineNumbers(diff, width), diff.Op, diff.Line, lineEnd(diff.Line))
 		}
 	}
 }
 
+func (p *Printer) defaultPrintMerge(r *MergeResult) {
+	for _, line := range r.Lines {
+		fmt.Fprint(p.w, line)
+	}
+}
+
+func lineNumberWidth(r *Result) int {
+	maxNumber := 0
+	for _, diff := range r.Diffs {
+		maxNumber = max(maxNumber, diff.LeftNumber, diff.RightNumber)
+	}
+	return len(strconv.Itoa(maxNumber))
+}
+
+func formatLineNumbers(diff LineDiff, width int) string {
+	if width == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%*s %*s ", width, formatLineNumber(diff.LeftNumber), width, formatLineNumber(diff.RightNumber))
+}
+
+func formatLineNumber(number int) string {
+	if number == 0 {
+		return ""
+	}
+	return strconv.Itoa(number)
+}
+
 // Formatter interface is used to format a diff result.
 type Formatter interface {
 	// Format is called to format the given diff result using
@@ -97,6 +192,22 @@ func (f FormatterFunc) Format(p *Printer, r *Result) {
 	f(p, r)
 }
 
+// MergeFormatter interface is used to format a merge result.
+type MergeFormatter interface {
+	// FormatMerge is called to format the given merge result using
+	// the given Printer instance.
+	FormatMerge(p *Printer, r *MergeResult)
+}
+
+// MergeFormatterFunc typed functions are used to format merge results.
+type MergeFormatterFunc func(*Printer, *MergeResult)
+
+// FormatMerge formats the given merge result using
+// the given Printer instance.
+func (f MergeFormatterFunc) FormatMerge(p *Printer, r *MergeResult) {
+	f(p, r)
+}
+
 // PrinterOption interface is used to configure a Printer instance.
 type PrinterOption interface {
 	// Apply applies the options represented by this instance
@@ -124,6 +235,39 @@ func WithAnsi(ansi bool) PrinterOption {
 	})
 }
 
+// WithLineNumbers enables or disables the output of the left
+// and right line numbers in the default output format.
+//
+// Per default no line numbers are printed.
+func WithLineNumbers(lineNumbers bool) PrinterOption {
+	return PrinterOptionFunc(func(p *Printer) {
+		p.lineNumbers = lineNumbers
+	})
+}
+
+// WithHighlight enables or disables the emphasis of the changed words
+// of paired deleted and added lines in the unified output format (see
+// [Colors.AddEmph] and [Colors.DelEmph]).
+//
+// Per default changed lines are colored as a whole.
+func WithHighlight(highlight bool) PrinterOption {
+	return PrinterOptionFunc(func(p *Printer) {
+		p.highlight = highlight
+	})
+}
+
+// WithWidth sets the output width (in columns) used by formatters
+// arranging the output in columns (see [WithSideBySideFormatter]).
+//
+// Per default the terminal width or [DefaultWidth] is used
+// (see [Printer.Width]). A width less or equal to 0 resets the
+// setting to the default.
+func WithWidth(width int) PrinterOption {
+	return PrinterOptionFunc(func(p *Printer) {
+		p.width = max(width, 0)
+	})
+}
+
 // WithColors sets the ansi sequences to use for coloring
 // the diff result.
 //
@@ -142,17 +286,33 @@ func WithFormatter(formatter Formatter) PrinterOption {
 	})
 }
 
+// WithMergeFormatter sets a custom MergeFormatter for formatting
+// the merge result.
+func WithMergeFormatter(formatter MergeFormatter) PrinterOption {
+	return PrinterOptionFunc(func(p *Printer) {
+		p.mergeFormatter = formatter
+	})
+}
+
 // NewPrinter creates a new Printer instance using the given
 // [io.Writer] and printer options.
 func NewPrinter(w io.Writer, opts ...PrinterOption) *Printer {
 	file, ok := w.(*os.File)
 	ansi := ok && (isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd()))
+	width := 0
+	if ansi {
+		width = terminalWidth(file.Fd())
+	}
 	printer := &Printer{
-		w:    w,
-		ansi: ansi,
+		w:             w,
+		ansi:          ansi,
+		terminalWidth: width,
 		formatter: FormatterFunc(func(p *Printer, r *Result) {
 			p.defaultPrint(r)
 		}),
+		mergeFormatter: MergeFormatterFunc(func(p *Printer, r *MergeResult) {
+			p.defaultPrintMerge(r)
+		}),
 	}
 	for _, opt := range opts {
 		opt.Apply(printer)
@@ -22,6 +22,7 @@ func TestPrinter(t *testing.T) {
 		testDefaultPrinter,
 		testPlainPrinter,
 		testAnsiPrinter,
+		testNumberedPrinter,
 	}
 	for _, setupPrinter := range setupPrinters {
 		t.Run(runtime.FuncForPC(reflect.ValueOf(setupPrinter).Pointer()).Name(), func(t *testing.T) {
@@ -67,3 +68,9 @@ func testAnsiPrinter(w io.Writer) (*diff.Printer, string) {
 	expected := "\x1b[0m> removed line\n\x1b[0m\x1b[0m= unchanged line\n\x1b[0m\x1b[0m< added line\n\x1b[0m"
 	return printer, expected
 }
+
+func testNumberedPrinter(w io.Writer) (*diff.Printer, string) {
+	printer := diff.NewPrinter(w, diff.WithAnsi(false), diff.WithLineNumbers(true))
+	expected := "1   > removed line\n2 1 = unchanged line\n  2 < added line\n"
+	return printer, expected
+}
@@ -0,0 +1,119 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SideBySideFlag defines optional behaviours of the side by side formatter.
+type SideBySideFlag int
+
+const (
+	// LeftColumnFlag prints only the left column for equal lines (like diff --left-column).
+	LeftColumnFlag SideBySideFlag = 1 << iota
+	// SuppressCommonLinesFlag omits equal lines (like diff --suppress-common-lines).
+	SuppressCommonLinesFlag
+)
+
+// sideBySideGutter defines the minimum width of the gutter between both columns.
+const sideBySideGutter int = 3
+
+// WithSideBySideFormatter wraps WithFormatter to format the ouput in two columns
+// (like diff -y -t).
+//
+// The columns are arranged according to the Printer's width (see [Printer.Width]).
+// Tabs are expanded and lines exceeding their column are truncated. Like GNU
+// diff, the tab stops are measured from the start of each column (and not from
+// the start of the output line).
+func WithSideBySideFormatter(flags ...SideBySideFlag) PrinterOption {
+	formatter := &sideBySideFormatter{}
+	for _, flag := range flags {
+		formatter.Flags |= flag
+	}
+	return PrinterOptionFunc(func(p *Printer) {
+		p.formatter = formatter
+	})
+}
+
+type sideBySideFormatter struct {
+	Flags SideBySideFlag
+}
+
+func (f *sideBySideFormatter) Format(p *Printer, r *Result) {
+	// arrange columns like GNU diff
+	width := p.Width()
+	offset := (width + 1 + sideBySideGutter) / 2
+	halfWidth := max(0, min(offset-sideBySideGutter, width-offset))
+	if halfWidth == 0 {
+		offset = width
+	}
+	gutter := (halfWidth + offset - 1) / 2
+	colors := p.Colors()
+	diffs := r.Diffs
+	for len(diffs) > 0 {
+		diff := diffs[0]
+		if diff.Op == EqlOp {
+			diffs = diffs[1:]
+			switch {
+			case f.Flags&SuppressCommonLinesFlag != 0:
+			case f.Flags&LeftColumnFlag != 0:
+				f.formatLine(p, diff.Line, "", '(', halfWidth, gutter, offset, colors.Eql, colors.Eql)
+			default:
+				f.formatLine(p, diff.Line, rightLine(diff), ' ', halfWidth, gutter, offset, colors.Eql, colors.Eql)
+			}
+			continue
+		}
+		// pair deleted and added lines of a block
+		dels := make([]string, 0)
+		adds := make([]string, 0)
+		for len(diffs) > 0 && diffs[0].Op != EqlOp {
+			if diffs[0].Op == DelOp {
+				dels = append(dels, diffs[0].Line)
+			} else {
+				adds = append(adds, diffs[0].Line)
+			}
+			diffs = diffs[1:]
+		}
+		for index := range max(len(dels), len(adds)) {
+			switch {
+			case index >= len(adds):
+				f.formatLine(p, dels[index], "", '<', halfWidth, gutter, offset, colors.Del, colors.Del)
+			case index >= len(dels):
+				f.formatLine(p, "", adds[index], '>', halfWidth, gutter, offset, colors.Add, colors.Add)
+			default:
+				f.formatLine(p, dels[index], adds[index], '|', halfWidth, gutter, offset, colors.Del, colors.Add)
+			}
+		}
+	}
+}
+
+func (f *sideBySideFormatter) formatLine(p *Printer, left string, right string, separator rune, halfWidth int, gutter int, offset int, leftSet string, rightSet string) {
+	rst := p.Colors().Rst
+	line := &strings.Builder{}
+	column := 0
+	// tab stops are relative to the column start (see WithSideBySideFormatter)
+	if left != "" {
+		text, textWidth := truncateWidth(expandTabs(strings.TrimSuffix(left, "\n"), 0), halfWidth)
+		fmt.Fprintf(line, "%s%s%s", leftSet, text, rst)
+		column += textWidth
+	}
+	if separator != ' ' {
+		line.WriteString(strings.Repeat(" ", max(gutter-column, 0)))
+		fmt.Fprintf(line, "%s%c%s", p.Colors().Lbl, separator, rst)
+		column = max(column, gutter) + 1
+	}
+	if right != "" && separator != '(' {
+		text, _ := truncateWidth(expandTabs(strings.TrimSuffix(right, "\n"), 0), halfWidth)
+		if text != "" {
+			line.WriteString(strings.Repeat(" ", max(offset-column, 0)))
+			fmt.Fprintf(line, "%s%s%s", rightSet, text, rst)
+		}
+	}
+	fmt.Fprintln(p, line.String())
+}
@@ -0,0 +1,62 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestSideBySide(t *testing.T) {
+	testSideBySide(t, "w40.diff", diff.WithWidth(40), diff.WithSideBySideFormatter())
+	testSideBySide(t, "w41.diff", diff.WithWidth(41), diff.WithSideBySideFormatter())
+	testSideBySide(t, "left.diff", diff.WithWidth(40), diff.WithSideBySideFormatter(diff.LeftColumnFlag))
+	testSideBySide(t, "suppress.diff", diff.WithWidth(40), diff.WithSideBySideFormatter(diff.SuppressCommonLinesFlag))
+	testSideBySide(t, "default.diff", diff.WithSideBySideFormatter())
+}
+
+func TestSideBySideTabs(t *testing.T) {
+	// expected output generated using diff -y -t -W 40
+	result := diff.DiffLines([]string{"a\tb\n", "same\n"}, []string{"xy\tz\n", "same\n"})
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithWidth(40), diff.WithSideBySideFormatter()).Print(result)
+	require.Equal(t, "a       b          |  xy      z\nsame                  same\n", output.String())
+}
+
+func TestSideBySideWide(t *testing.T) {
+	result := diff.DiffLines([]string{"日本語テキスト\n", "e\u0301\n"}, []string{"日本語\n", "e\u0301\n"})
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithWidth(20), diff.WithSideBySideFormatter()).Print(result)
+	require.Equal(t, "日本語テ |  日本語\ne\u0301           e\u0301\n", output.String())
+}
+
+func TestSideBySideAnsi(t *testing.T) {
+	result := diff.DiffLines([]string{"a\n", "b\n"}, []string{"a\n", "c\n"})
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithWidth(20), diff.WithSideBySideFormatter()).Print(result)
+	require.Equal(t, "\x1b[97ma\x1b[0m           \x1b[97ma\x1b[0m\n\x1b[31mb\x1b[0m        \x1b[96m|\x1b[0m  \x1b[32mc\x1b[0m\n", output.String())
+}
+
+func TestPrinterWidth(t *testing.T) {
+	require.Equal(t, diff.DefaultWidth, diff.NewPrinter(&strings.Builder{}).Width())
+	require.Equal(t, 80, diff.NewPrinter(&strings.Builder{}, diff.WithWidth(80)).Width())
+	require.Equal(t, diff.DefaultWidth, diff.NewPrinter(&strings.Builder{}, diff.WithWidth(80), diff.WithWidth(0)).Width())
+}
+
+func testSideBySide(t *testing.T, expectedName string, opts ...diff.PrinterOption) {
+	result, err := diff.DiffFiles("testdata/sidebyside/l.txt", "testdata/sidebyside/r.txt")
+	require.NoError(t, err)
+	expected, err := os.ReadFile("testdata/sidebyside/" + expectedName)
+	require.NoError(t, err)
+	output := &strings.Builder{}
+	diff.NewPrinter(output, append([]diff.PrinterOption{diff.WithAnsi(false)}, opts...)...).Print(result)
+	require.Equal(t, string(expected), output.String())
+}
@@ -0,0 +1,13 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+//go:build !unix && !windows
+
+package diff
+
+func terminalWidth(fd uintptr) int {
+	return 0
+}
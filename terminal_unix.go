@@ -0,0 +1,21 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+//go:build unix
+
+package diff
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+func terminalWidth(fd uintptr) int {
+	winsize, err := unix.IoctlGetWinsize(int(fd), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0
+	}
+	return int(winsize.Col)
+}
@@ -0,0 +1,22 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+//go:build windows
+
+package diff
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+func terminalWidth(fd uintptr) int {
+	var info windows.ConsoleScreenBufferInfo
+	err := windows.GetConsoleScreenBufferInfo(windows.Handle(fd), &info)
+	if err != nil {
+		return 0
+	}
+	return int(info.Window.Right-info.Window.Left) + 1
+}
@@ -27,52 +27,41 @@ func WithUnifiedFormatter(context int) PrinterOption {
 }
 
 type unifiedFormatter struct {
-	Context        int
-	leftLine       int
-	rightLine      int
-	hunk           bool
-	hunkIndex      int
-	eqlRun         int
-	hunkStartLeft  int
-	hunkStartRight int
+	Context int
 }
 
 func (f *unifiedFormatter) Format(p *Printer, r *Result) {
 	f.formatHeader(p, r)
-	f.leftLine = 0
-	f.rightLine = 0
-	f.hunk = false
-	f.eqlRun = 0
-	for index, diff := range r.Diffs {
-		if f.hunk {
-			f.evalDiffInsideHunk(diff)
-			if !f.hunk {
-				f.formatHunk(p, r.Diffs)
-			}
-		} else {
-			f.evalDiffOutsideHunk(diff)
-			if f.hunk {
-				f.hunkIndex = max(index-f.Context, 0)
+	colors := p.Colors()
+	highlight := p.highlight && p.Ansi() && colors.AddEmph != "" && colors.DelEmph != ""
+	for hunk := range r.Hunks(f.Context) {
+		f.formatRange(p, hunk)
+		var segments [][]lineSegment
+		if highlight {
+			segments = highlightHunk(hunk)
+		}
+		for index, diff := range hunk.Diffs {
+			if segments != nil && segments[index] != nil && !diff.Moved() {
+				f.formatSegments(p, diff, segments[index])
+			} else {
+				f.formatDiff(p, diff)
 			}
 		}
 	}
-	if f.hunk {
-		f.formatHunk(p, r.Diffs)
-	}
 }
 
 func (f *unifiedFormatter) formatHeader(p *Printer, r *Result) {
 	if p.Ansi() {
 		colors := p.Colors()
-		fmt.Fprintf(p, "%s--- %s\t%s%s\n", colors.Hdr, r.LeftName, f.modificationTime(r.LeftName), colors.Rst)
-		fmt.Fprintf(p, "%s+++ %s\t%s%s\n", colors.Hdr, r.RightName, f.modificationTime(r.RightName), colors.Rst)
+		fmt.Fprintf(p, "%s--- %s\t%s%s\n", colors.Hdr, r.LeftName, modificationTime(r.LeftName), colors.Rst)
+		fmt.Fprintf(p, "%s+++ %s\t%s%s\n", colors.Hdr, r.RightName, modificationTime(r.RightName), colors.Rst)
 	} else {
-		fmt.Fprintf(p, "--- %s\t%s\n", r.LeftName, f.modificationTime(r.LeftName))
-		fmt.Fprintf(p, "+++ %s\t%s\n", r.RightName, f.modificationTime(r.RightName))
+		fmt.Fprintf(p, "--- %s\t%s\n", r.LeftName, modificationTime(r.LeftName))
+		fmt.Fprintf(p, "+++ %s\t%s\n", r.RightName, modificationTime(r.RightName))
 	}
 }
 
-func (f *unifiedFormatter) modificationTime(name string) string {
+func modificationTime(name string) string {
 	now := time.Now()
 	if name == DefaultLeftName || name == DefaultRightName {
 		return now.String()
@@ -84,96 +73,29 @@ func (f *unifiedFormatter) modificationTime(name string) string {
 	return stat.ModTime().String()
 }
 
-func (f *unifiedFormatter) evalDiffInsideHunk(diff LineDiff) {
-	switch diff.Op {
-	case EqlOp:
-		f.eqlRun++
-		f.hunk = f.eqlRun <= 2*f.Context
-		f.leftLine++
-		f.rightLine++
-	case AddOp:
-		f.eqlRun = 0
-		f.rightLine++
-	case DelOp:
-		f.eqlRun = 0
-		f.leftLine++
-	}
-}
-
-func (f *unifiedFormatter) evalDiffOutsideHunk(diff LineDiff) {
-	switch diff.Op {
-	case EqlOp:
-		f.leftLine++
-		f.rightLine++
-	case AddOp:
-		f.hunk = true
-		f.hunkStartLeft = max(f.leftLine-f.Context, 0)
-		f.hunkStartRight = max(f.rightLine-f.Context, 0)
-		f.rightLine++
-	case DelOp:
-		f.hunk = true
-		f.hunkStartLeft = max(f.leftLine-f.Context, 0)
-		f.hunkStartRight = max(f.rightLine-f.Context, 0)
-		f.leftLine++
-	}
-}
-
-func (f *unifiedFormatter) formatHunk(p *Printer, diffs []LineDiff) {
-	hunkExtentLeft := f.leftLine - f.hunkStartLeft
-	hunkExtentRight := f.rightLine - f.hunkStartRight
-	if !f.hunk {
-		hunkExtentLeft -= f.Context + 1
-		hunkExtentRight -= f.Context + 1
-	}
-	f.formatRange(p, f.hunkStartLeft, hunkExtentLeft, f.hunkStartRight, hunkExtentRight)
-	hunkLineLeft := f.hunkStartLeft
-	hunkRemainingLeft := hunkExtentLeft
-	hunkLineRight := f.hunkStartRight
-	hunkRemainingRight := hunkExtentRight
-	for _, diff := range diffs[f.hunkIndex:] {
-		f.formatDiff(p, diff)
-		switch diff.Op {
-		case EqlOp:
-			hunkLineLeft++
-			hunkRemainingLeft--
-			hunkLineRight++
-			hunkRemainingRight--
-		case AddOp:
-			hunkLineRight++
-			hunkRemainingRight--
-		case DelOp:
-			hunkLineLeft++
-			hunkRemainingLeft--
-		}
-		if hunkRemainingLeft == 0 && hunkRemainingRight == 0 {
-			break
-		}
-	}
-}
-
-func (f *unifiedFormatter) formatRange(p *Printer, startLeft int, extentLeft int, startRight int, extentRight int) {
+func (f *unifiedFormatter) formatRange(p *Printer, hunk Hunk) {
 	if p.Ansi() {
-		fmt.Fprintf(p, "%s@@ -%d,%d +%d,%d @@%s\n", ansiLbl, startLeft+1, extentLeft, startRight+1, extentRight, ansiRst)
+		colors := p.Colors()
+		fmt.Fprintf(p, "%s@@ -%d,%d +%d,%d @@%s\n", colors.Lbl, hunk.LeftStart, hunk.LeftLength, hunk.RightStart, hunk.RightLength, colors.Rst)
 	} else {
-		fmt.Fprintf(p, "@@ -%d,%d +%d,%d @@\n", startLeft+1, extentLeft, startRight+1, extentRight)
+		fmt.Fprintf(p, "@@ -%d,%d +%d,%d @@\n", hunk.LeftStart, hunk.LeftLength, hunk.RightStart, hunk.RightLength)
 	}
 }
 
 func (f *unifiedFormatter) formatDiff(p *Printer, diff LineDiff) {
 	if p.Ansi() {
-		colors := p.Colors()
 		var op string
-		var set string
-		var rst string
 		switch diff.Op {
 		case EqlOp:
-			op, set, rst = " ", colors.Eql, colors.Rst
+			op = " "
 		case AddOp:
-			op, set, rst = "+", colors.Add, colors.Rst
+			op = "+"
 		case DelOp:
-			op, set, rst = "-", colors.Del, colors.Rst
+			op = "-"
 		}
+		set, rst := p.LineColor(diff)
 		fmt.Fprintf(p.w, "%s%s%s%s", set, op, diff.Line, rst)
+		f.formatNoNewline(p, diff.Line)
 	} else {
 		var op string
 		switch diff.Op {
@@ -185,5 +107,30 @@ func (f *unifiedFormatter) formatDiff(p *Printer, diff LineDiff) {
 			op = "-"
 		}
 		fmt.Fprintf(p.w, "%s%s", op, diff.Line)
+		f.formatNoNewline(p, diff.Line)
+	}
+}
+
+func (f *unifiedFormatter) formatSegments(p *Printer, diff LineDiff, segments []lineSegment) {
+	colors := p.Colors()
+	op, set, emph := "+", colors.Add, colors.AddEmph
+	if diff.Op == DelOp {
+		op, set, emph = "-", colors.Del, colors.DelEmph
+	}
+	fmt.Fprintf(p.w, "%s%s", set, op)
+	for _, segment := range segments {
+		if segment.emphasized {
+			fmt.Fprintf(p.w, "%s%s%s%s%s", colors.Rst, emph, segment.text, colors.Rst, set)
+		} else {
+			fmt.Fprint(p.w, segment.text)
+		}
+	}
+	fmt.Fprint(p.w, colors.Rst)
+	f.formatNoNewline(p, diff.Line)
+}
+
+func (f *unifiedFormatter) formatNoNewline(p *Printer, line string) {
+	if lineEnd(line) != "" {
+		fmt.Fprint(p.w, "\n"+noNewlineMarker)
 	}
 }
@@ -39,3 +39,42 @@ func TestUnified(t *testing.T) {
 		require.Equal(t, expectedUnifiedDiffAnsi, output.String())
 	}
 }
+
+func TestUnifiedNoNewline(t *testing.T) {
+	result, err := diff.Diff(strings.NewReader("a\nb"), strings.NewReader("a\nb\nc"))
+	require.NoError(t, err)
+	result.LeftName = "./" + diff.DefaultLeftName
+	result.RightName = "./" + diff.DefaultRightName
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(false), diff.WithUnifiedFormatter(diff.DefaultUnifiedContext)).Print(result)
+	require.Equal(t, "--- ./l.txt\t0001-01-01 00:00:00 +0000 UTC\n+++ ./r.txt\t0001-01-01 00:00:00 +0000 UTC\n@@ -1,2 +1,3 @@\n a\n-b\n\\ No newline at end of file\n+b\n+c\n\\ No newline at end of file\n", output.String())
+}
+
+func TestUnifiedHighlight(t *testing.T) {
+	left := []string{"a\n", "func foo(x int) {\n", "c\n"}
+	right := []string{"a\n", "func bar(x int) {\n", "c\n"}
+	result := diff.DiffLines(left, right, diff.WithLeftName("./l.txt"), diff.WithRightName("./r.txt"))
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithHighlight(true), diff.WithUnifiedFormatter(0)).Print(result)
+	lines := strings.SplitAfter(output.String(), "\n")
+	require.Equal(t, "\x1b[31m-func \x1b[0m\x1b[7;31mfoo\x1b[0m\x1b[31m(x int) {\n", lines[3])
+	require.Equal(t, "\x1b[0m\x1b[32m+func \x1b[0m\x1b[7;32mbar\x1b[0m\x1b[32m(x int) {\n", lines[4])
+
+	// lines without common words are not refined
+	result = diff.DiffLines([]string{"foo\n"}, []string{"bar\n"}, diff.WithLeftName("./l.txt"), diff.WithRightName("./r.txt"))
+	output.Reset()
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithHighlight(true), diff.WithUnifiedFormatter(0)).Print(result)
+	require.Contains(t, output.String(), "\x1b[31m-foo\n\x1b[0m\x1b[32m+bar\n\x1b[0m")
+
+	// colors without emphasis disable refinement
+	colors := &diff.Colors{Add: "+", Del: "-", Rst: "."}
+	result = diff.DiffLines(left, right, diff.WithLeftName("./l.txt"), diff.WithRightName("./r.txt"))
+	output.Reset()
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithHighlight(true), diff.WithColors(colors), diff.WithUnifiedFormatter(0)).Print(result)
+	require.Contains(t, output.String(), "--func foo(x int) {\n.++func bar(x int) {\n.")
+
+	// changed lines are colored as a whole per default
+	output.Reset()
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithUnifiedFormatter(0)).Print(result)
+	require.Contains(t, output.String(), "\x1b[31m-func foo(x int) {\n\x1b[0m\x1b[32m+func bar(x int) {\n\x1b[0m")
+}
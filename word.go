@@ -0,0 +1,249 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultWordRegexp defines the default regular expression (sequences of
+// non white space characters) used to split text into words.
+const DefaultWordRegexp string = `\S+`
+
+var defaultWordRegexp = regexp.MustCompile(DefaultWordRegexp)
+
+// WordDiff represents a part of a word diff result.
+type WordDiff struct {
+	// Op indicates the diff operation associated with this part.
+	Op Op
+	// Text contains the text of this part. For changed parts, the text spans
+	// from the first to the last changed word (see [DiffWords] for the case
+	// of an empty right text). Unchanged parts also contain the text
+	// separating the words.
+	Text string
+}
+
+// DiffWords runs a diff operation on the words of the two given texts using
+// the given diff options.
+//
+// The words are determined by the given regular expression. Text not matched
+// by the regular expression separates the words and is not compared. If the
+// regular expression is nil, [DefaultWordRegexp] is used. Like git, the
+// unchanged parts of the result contain the separating text of the right
+// text only. If the right text is empty, the whole left text (including
+// its separating text) is considered deleted.
+func DiffWords(left string, right string, wordRegexp *regexp.Regexp, opts ...DiffOption) []WordDiff {
+	if wordRegexp == nil {
+		wordRegexp = defaultWordRegexp
+	}
+	if right == "" {
+		// there is no separating text to take from the right text
+		return appendWordDiff(make([]WordDiff, 0), DelOp, left)
+	}
+	leftWords := splitWords(left, wordRegexp)
+	rightWords := splitWords(right, wordRegexp)
+	script := DiffSlices(wordTexts(left, leftWords), wordTexts(right, rightWords), opts...)
+	diffs := make([]WordDiff, 0)
+	rightPos := 0
+	edits := script.Edits
+	for len(edits) > 0 {
+		if edits[0].Op == EqlOp {
+			rightWord := rightWords[edits[0].RightIndex]
+			diffs = appendWordDiff(diffs, EqlOp, right[rightPos:rightWord[1]])
+			rightPos = rightWord[1]
+			edits = edits[1:]
+			continue
+		}
+		// collect the deleted and added words up to the next unchanged word
+		delStart, delEnd, addStart, addEnd := -1, -1, -1, -1
+		for len(edits) > 0 && edits[0].Op != EqlOp {
+			if edits[0].Op == DelOp {
+				if delStart < 0 {
+					delStart = leftWords[edits[0].LeftIndex][0]
+				}
+				delEnd = leftWords[edits[0].LeftIndex][1]
+			} else {
+				if addStart < 0 {
+					addStart = rightWords[edits[0].RightIndex][0]
+				}
+				addEnd = rightWords[edits[0].RightIndex][1]
+			}
+			edits = edits[1:]
+		}
+		if addStart >= 0 {
+			diffs = appendWordDiff(diffs, EqlOp, right[rightPos:addStart])
+		}
+		if delStart >= 0 {
+			diffs = appendWordDiff(diffs, DelOp, left[delStart:delEnd])
+		}
+		if addStart >= 0 {
+			diffs = appendWordDiff(diffs, AddOp, right[addStart:addEnd])
+			rightPos = addEnd
+		}
+	}
+	return appendWordDiff(diffs, EqlOp, right[rightPos:])
+}
+
+func splitWords(text string, wordRegexp *regexp.Regexp) [][]int {
+	words := make([][]int, 0)
+	for _, word := range wordRegexp.FindAllStringIndex(text, -1) {
+		if word[0] < word[1] {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+func wordTexts(text string, words [][]int) []string {
+	texts := make([]string, 0, len(words))
+	for _, word := range words {
+		texts = append(texts, text[word[0]:word[1]])
+	}
+	return texts
+}
+
+func appendWordDiff(diffs []WordDiff, op Op, text string) []WordDiff {
+	if text == "" {
+		return diffs
+	}
+	last := len(diffs) - 1
+	if last >= 0 && diffs[last].Op == op {
+		diffs[last].Text += text
+		return diffs
+	}
+	return append(diffs, WordDiff{Op: op, Text: text})
+}
+
+// WordDiffMode defines how the word diff formatter marks changed words.
+type WordDiffMode int
+
+const (
+	// PlainWordDiff marks changed words with [-deleted-] and {+added+} (default).
+	PlainWordDiff WordDiffMode = 0
+	// ColorWordDiff marks changed words using the Printer's colors only.
+	// If coloring is disabled, changed words are marked like in plain mode.
+	ColorWordDiff WordDiffMode = 1
+	// PorcelainWordDiff prints unchanged, deleted and added words on lines of their
+	// own prefixed with ' ', '-' and '+'. Line ends are printed as a line '~'.
+	PorcelainWordDiff WordDiffMode = 2
+)
+
+// WithWordDiffFormatter wraps WithFormatter to format the ouput as word diff
+// (like git diff --word-diff).
+//
+// The output is grouped in hunks like the unified diff output. Within each
+// hunk, changed lines are diffed word by word (see [DiffWords]) using the
+// given regular expression (nil selects [DefaultWordRegexp]).
+func WithWordDiffFormatter(context int, mode WordDiffMode, wordRegexp *regexp.Regexp) PrinterOption {
+	checkedContext := context
+	if checkedContext < 0 {
+		checkedContext = DefaultUnifiedContext
+	}
+	if wordRegexp == nil {
+		wordRegexp = defaultWordRegexp
+	}
+	return PrinterOptionFunc(func(p *Printer) {
+		p.formatter = &wordFormatter{Context: checkedContext, Mode: mode, Regexp: wordRegexp}
+	})
+}
+
+type wordFormatter struct {
+	Context int
+	Mode    WordDiffMode
+	Regexp  *regexp.Regexp
+}
+
+func (f *wordFormatter) Format(p *Printer, r *Result) {
+	unified := &unifiedFormatter{Context: f.Context}
+	unified.formatHeader(p, r)
+	for hunk := range r.Hunks(f.Context) {
+		unified.formatRange(p, hunk)
+		diffs := hunk.Diffs
+		for len(diffs) > 0 {
+			if diffs[0].Op == EqlOp {
+				f.formatEqualLine(p, rightLine(diffs[0]))
+				diffs = diffs[1:]
+				continue
+			}
+			left := &strings.Builder{}
+			right := &strings.Builder{}
+			for len(diffs) > 0 && diffs[0].Op != EqlOp {
+				if diffs[0].Op == DelOp {
+					left.WriteString(diffs[0].Line + lineEnd(diffs[0].Line))
+				} else {
+					right.WriteString(diffs[0].Line + lineEnd(diffs[0].Line))
+				}
+				diffs = diffs[1:]
+			}
+			f.formatWordDiffs(p, DiffWords(left.String(), right.String(), f.Regexp))
+		}
+	}
+}
+
+func (f *wordFormatter) formatEqualLine(p *Printer, line string) {
+	if f.Mode == PorcelainWordDiff {
+		// unlike unchanged words, unchanged lines are always prefixed
+		fmt.Fprintf(p, "%s%s%s~\n", f.porcelainPrefix(EqlOp), line, lineEnd(line))
+		return
+	}
+	f.formatWordDiffs(p, []WordDiff{{Op: EqlOp, Text: line + lineEnd(line)}})
+}
+
+func (f *wordFormatter) formatWordDiffs(p *Printer, diffs []WordDiff) {
+	colors := p.Colors()
+	mode := f.Mode
+	if mode == ColorWordDiff && !p.Ansi() {
+		mode = PlainWordDiff
+	}
+	for _, diff := range diffs {
+		// markers must not span multiple lines
+		lines := strings.SplitAfter(diff.Text, "\n")
+		for _, line := range lines {
+			text := strings.TrimSuffix(line, "\n")
+			if text != "" {
+				switch mode {
+				case PorcelainWordDiff:
+					fmt.Fprintf(p, "%s%s\n", f.porcelainPrefix(diff.Op), text)
+				case ColorWordDiff:
+					set, rst := p.OpColor(diff.Op)
+					if diff.Op == EqlOp {
+						set, rst = "", ""
+					}
+					fmt.Fprintf(p, "%s%s%s", set, text, rst)
+				default:
+					switch diff.Op {
+					case AddOp:
+						fmt.Fprintf(p, "%s{+%s+}%s", colors.Add, text, colors.Rst)
+					case DelOp:
+						fmt.Fprintf(p, "%s[-%s-]%s", colors.Del, text, colors.Rst)
+					default:
+						fmt.Fprint(p, text)
+					}
+				}
+			}
+			if strings.HasSuffix(line, "\n") {
+				if mode == PorcelainWordDiff {
+					fmt.Fprintln(p, "~")
+				} else {
+					fmt.Fprintln(p)
+				}
+			}
+		}
+	}
+}
+
+func (f *wordFormatter) porcelainPrefix(op Op) string {
+	switch op {
+	case AddOp:
+		return "+"
+	case DelOp:
+		return "-"
+	}
+	return " "
+}
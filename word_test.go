@@ -0,0 +1,67 @@
+//
+// Copyright (C) 2025-2026 Holger de Carne
+//
+// This software may be modified and distributed under the terms
+// of the MIT license. See the LICENSE file for details.
+
+package diff_test
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdrn-org/go-diff"
+)
+
+func TestDiffWords(t *testing.T) {
+	require.Equal(t, []diff.WordDiff{
+		{Op: diff.EqlOp, Text: "the "},
+		{Op: diff.DelOp, Text: "quick"},
+		{Op: diff.AddOp, Text: "slow"},
+		{Op: diff.EqlOp, Text: " brown fox"},
+	}, diff.DiffWords("the quick brown fox", "the slow brown fox", nil))
+	require.Equal(t, []diff.WordDiff{
+		{Op: diff.EqlOp, Text: "a"},
+		{Op: diff.DelOp, Text: "b  b"},
+		{Op: diff.EqlOp, Text: "  c"},
+	}, diff.DiffWords("a b  b c", "a  c", nil))
+	require.Equal(t, []diff.WordDiff{
+		{Op: diff.EqlOp, Text: "foo"},
+		{Op: diff.DelOp, Text: "_bar"},
+		{Op: diff.AddOp, Text: "Bar"},
+		{Op: diff.EqlOp, Text: "(x)"},
+	}, diff.DiffWords("foo_bar(x)", "fooBar(x)", regexp.MustCompile(`[A-Z]?[a-z]+|_[a-z]+|[^[:space:]]`)))
+	require.Equal(t, []diff.WordDiff{
+		{Op: diff.DelOp, Text: "\tb c\n"},
+	}, diff.DiffWords("\tb c\n", "", nil))
+	require.Empty(t, diff.DiffWords("", "", nil))
+}
+
+func TestWordDiffFormatter(t *testing.T) {
+	for _, prefix := range []string{"testdata/word/", "testdata/word/deleted_"} {
+		testWordDiffFormatter(t, prefix, "plain.diff", diff.WithAnsi(false), diff.WithWordDiffFormatter(diff.DefaultUnifiedContext, diff.PlainWordDiff, nil))
+		testWordDiffFormatter(t, prefix, "porcelain.diff", diff.WithAnsi(false), diff.WithWordDiffFormatter(diff.DefaultUnifiedContext, diff.PorcelainWordDiff, nil))
+		// color mode falls back to plain mode, if coloring is disabled
+		testWordDiffFormatter(t, prefix, "plain.diff", diff.WithAnsi(false), diff.WithWordDiffFormatter(diff.DefaultUnifiedContext, diff.ColorWordDiff, nil))
+	}
+}
+
+func TestWordDiffFormatterColor(t *testing.T) {
+	result := diff.DiffLines([]string{"a\n", "b c d\n"}, []string{"a\n", "b x d\n"}, diff.WithLeftName("./l.txt"), diff.WithRightName("./r.txt"))
+	output := &strings.Builder{}
+	diff.NewPrinter(output, diff.WithAnsi(true), diff.WithWordDiffFormatter(0, diff.ColorWordDiff, nil)).Print(result)
+	require.Equal(t, "\x1b[96m@@ -2,1 +2,1 @@\x1b[0m\nb \x1b[31mc\x1b[0m\x1b[32mx\x1b[0m d\n", testStripContextHeader(output.String()))
+}
+
+func testWordDiffFormatter(t *testing.T, prefix string, expectedName string, opts ...diff.PrinterOption) {
+	result, err := diff.DiffFiles(prefix+"l.txt", prefix+"r.txt")
+	require.NoError(t, err)
+	expected, err := os.ReadFile(prefix + expectedName)
+	require.NoError(t, err)
+	output := &strings.Builder{}
+	diff.NewPrinter(output, opts...).Print(result)
+	require.Equal(t, string(expected), testStripContextHeader(output.String()))
+}